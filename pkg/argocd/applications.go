@@ -8,6 +8,7 @@ import (
 	"github.com/openshift-kni/eco-goinfra/pkg/argocd/argocdtypes"
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"golang.org/x/exp/slices"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -20,6 +21,8 @@ const (
 	APIGroup = "argoproj.io"
 	// APIVersion const definition.
 	APIVersion = "v1alpha1"
+	// ResourcesFinalizerName is the finalizer that makes argocd delete an application's resources along with it.
+	ResourcesFinalizerName = "resources-finalizer.argocd.argoproj.io"
 )
 
 // ApplicationBuilder provides a struct for an application object from the cluster and a definition.
@@ -189,6 +192,59 @@ func (builder *ApplicationBuilder) Delete() (*ApplicationBuilder, error) {
 	return builder, nil
 }
 
+// DeleteWithCascade removes the argocd application object from a cluster, first updating its resources finalizer so
+// that its managed resources are deleted along with it if cascade is true or left in place if cascade is false.
+func (builder *ApplicationBuilder) DeleteWithCascade(cascade bool) (*ApplicationBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Deleting the argocd application object %s from namespace %s with cascade %t",
+		builder.Definition.Name, builder.Definition.Namespace, cascade)
+
+	if !builder.Exists() {
+		glog.V(100).Infof("application %s in namespace %s cannot be deleted because it does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		builder.Object = nil
+
+		return builder, nil
+	}
+
+	if builder.Object == nil {
+		return builder, fmt.Errorf("failed to get application object %s in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	hasFinalizer := slices.Contains(builder.Object.Finalizers, ResourcesFinalizerName)
+
+	if cascade != hasFinalizer {
+		if cascade {
+			builder.Object.Finalizers = append(builder.Object.Finalizers, ResourcesFinalizerName)
+		} else {
+			builder.Object.Finalizers = slices.DeleteFunc(builder.Object.Finalizers, func(finalizer string) bool {
+				return finalizer == ResourcesFinalizerName
+			})
+		}
+
+		unstructuredApplication, err := runtime.DefaultUnstructuredConverter.ToUnstructured(builder.Object)
+		if err != nil {
+			glog.V(100).Infof("Failed to convert structured Application to unstructured object")
+
+			return builder, err
+		}
+
+		_, err = builder.apiClient.Resource(
+			GetApplicationsGVR()).Namespace(builder.Definition.Namespace).Update(
+			context.TODO(), &unstructured.Unstructured{Object: unstructuredApplication}, metav1.UpdateOptions{})
+		if err != nil {
+			return builder, fmt.Errorf("can not update finalizers of argocd application: %w", err)
+		}
+	}
+
+	return builder.Delete()
+}
+
 // Create makes an argocd application in the cluster and stores the created object in a struct.
 func (builder *ApplicationBuilder) Create() (*ApplicationBuilder, error) {
 	if valid, err := builder.validate(); !valid {
@@ -269,6 +325,136 @@ func (builder *ApplicationBuilder) WithGitDetails(gitRepo, gitBranch, gitPath st
 	return builder
 }
 
+// WithHelmChart applies the details of a chart from a helm repository to the application definition.
+func (builder *ApplicationBuilder) WithHelmChart(repoURL, chart, targetRevision string) *ApplicationBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if repoURL == "" {
+		glog.V(100).Infof("The 'repoURL' of the argocd application is empty")
+
+		builder.errorMsg = "'repoURL' parameter is empty"
+	}
+
+	if chart == "" {
+		glog.V(100).Infof("The 'chart' of the argocd application is empty")
+
+		builder.errorMsg = "'chart' parameter is empty"
+	}
+
+	if targetRevision == "" {
+		glog.V(100).Infof("The 'targetRevision' of the argocd application is empty")
+
+		builder.errorMsg = "'targetRevision' parameter is empty"
+	}
+
+	glog.V(100).Infof(
+		"Adding the following helm chart details to the argocd application: %s in namespace: %s "+
+			"RepoURL: %s, Chart: %s, TargetRevision: %s", builder.Definition.Name, builder.Definition.Namespace,
+		repoURL, chart, targetRevision,
+	)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	if builder.Definition.Spec.Source == nil {
+		builder.Definition.Spec.Source = &argocdtypes.ApplicationSource{}
+	}
+
+	builder.Definition.Spec.Source.RepoURL = repoURL
+	builder.Definition.Spec.Source.Chart = chart
+	builder.Definition.Spec.Source.TargetRevision = targetRevision
+
+	return builder
+}
+
+// WithDirectory applies the directory options to the source of the application definition. The include and exclude
+// glob patterns are optional and are left unset when empty.
+func (builder *ApplicationBuilder) WithDirectory(recurse bool, include, exclude string) *ApplicationBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Adding the following directory options to the argocd application: %s in namespace: %s "+
+			"Recurse: %t, Include: %s, Exclude: %s", builder.Definition.Name, builder.Definition.Namespace,
+		recurse, include, exclude,
+	)
+
+	if builder.Definition.Spec.Source == nil {
+		builder.Definition.Spec.Source = &argocdtypes.ApplicationSource{}
+	}
+
+	if builder.Definition.Spec.Source.Directory == nil {
+		builder.Definition.Spec.Source.Directory = &argocdtypes.ApplicationSourceDirectory{}
+	}
+
+	builder.Definition.Spec.Source.Directory.Recurse = recurse
+	builder.Definition.Spec.Source.Directory.Include = include
+	builder.Definition.Spec.Source.Directory.Exclude = exclude
+
+	return builder
+}
+
+// WithCascadeDeletion adds the resources finalizer to the application definition so that its managed resources are
+// deleted when the application is deleted.
+func (builder *ApplicationBuilder) WithCascadeDeletion() *ApplicationBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding finalizer %s to the argocd application: %s in namespace: %s",
+		ResourcesFinalizerName, builder.Definition.Name, builder.Definition.Namespace)
+
+	if !slices.Contains(builder.Definition.Finalizers, ResourcesFinalizerName) {
+		builder.Definition.Finalizers = append(builder.Definition.Finalizers, ResourcesFinalizerName)
+	}
+
+	return builder
+}
+
+// WithoutCascadeDeletion removes the resources finalizer from the application definition so that its managed
+// resources are left in place when the application is deleted.
+func (builder *ApplicationBuilder) WithoutCascadeDeletion() *ApplicationBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Removing finalizer %s from the argocd application: %s in namespace: %s",
+		ResourcesFinalizerName, builder.Definition.Name, builder.Definition.Namespace)
+
+	builder.Definition.Finalizers = slices.DeleteFunc(builder.Definition.Finalizers, func(finalizer string) bool {
+		return finalizer == ResourcesFinalizerName
+	})
+
+	return builder
+}
+
+// GetManagedResources refreshes the application from the cluster and returns the resources it manages, along with
+// their sync and health status, as reported in its status.
+func (builder *ApplicationBuilder) GetManagedResources() ([]argocdtypes.ResourceStatus, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting managed resources of argocd app %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("application object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	if builder.Object == nil {
+		return nil, fmt.Errorf("failed to get application object %s in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return builder.Object.Status.Resources, nil
+}
+
 // GetApplicationsGVR returns applications GroupVersionResource which could be used for Clean function.
 func GetApplicationsGVR() schema.GroupVersionResource {
 	return schema.GroupVersionResource{
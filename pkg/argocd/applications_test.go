@@ -7,9 +7,12 @@ import (
 	"github.com/openshift-kni/eco-goinfra/pkg/argocd/argocdtypes"
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
 var (
@@ -213,6 +216,78 @@ func TestApplicationDelete(t *testing.T) {
 	}
 }
 
+func TestApplicationDeleteWithCascade(t *testing.T) {
+	testCases := []struct {
+		finalizers         []string
+		cascade            bool
+		expectedFinalizers []string
+	}{
+		{
+			finalizers:         nil,
+			cascade:            true,
+			expectedFinalizers: []string{ResourcesFinalizerName},
+		},
+		{
+			finalizers:         []string{ResourcesFinalizerName},
+			cascade:            true,
+			expectedFinalizers: []string{ResourcesFinalizerName},
+		},
+		{
+			finalizers:         []string{ResourcesFinalizerName, "test-finalizer"},
+			cascade:            false,
+			expectedFinalizers: []string{"test-finalizer"},
+		},
+		{
+			finalizers:         nil,
+			cascade:            false,
+			expectedFinalizers: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		application := buildDummyApplication(defaultApplicationName, defaultApplicationNsName)
+		application.Finalizers = testCase.finalizers
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: []runtime.Object{application},
+			GVK:            []schema.GroupVersionKind{applicationGVK},
+		})
+
+		fakeDynamicClient, ok := testSettings.Interface.(*dynamicfake.FakeDynamicClient)
+		assert.True(t, ok)
+
+		// Record the finalizers of the application at the time it is deleted.
+		var deletedFinalizers []string
+
+		fakeDynamicClient.PrependReactor("delete", "applications",
+			func(action k8stesting.Action) (bool, runtime.Object, error) {
+				object, err := fakeDynamicClient.Tracker().Get(
+					GetApplicationsGVR(), defaultApplicationNsName, defaultApplicationName)
+				if err != nil {
+					return false, nil, nil
+				}
+
+				if accessor, err := meta.Accessor(object); err == nil {
+					deletedFinalizers = accessor.GetFinalizers()
+				}
+
+				return false, nil, nil
+			})
+
+		applicationBuilder, err := buildValidApplicationBuilder(testSettings).DeleteWithCascade(testCase.cascade)
+		assert.Nil(t, err)
+		assert.Nil(t, applicationBuilder.Object)
+		assert.Equal(t, testCase.expectedFinalizers, deletedFinalizers)
+		assert.False(t, applicationBuilder.Exists())
+	}
+
+	// Check that deleting an application that does not exist is a no-op.
+	applicationBuilder, err := buildValidApplicationBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		DeleteWithCascade(false)
+	assert.Nil(t, err)
+	assert.Nil(t, applicationBuilder.Object)
+}
+
 func TestApplicationCreate(t *testing.T) {
 	testCases := []struct {
 		testApplicationBuilder *ApplicationBuilder
@@ -296,6 +371,194 @@ func TestApplicationWithGitDetails(t *testing.T) {
 	}
 }
 
+func TestApplicationWithHelmChart(t *testing.T) {
+	testCases := []struct {
+		repoURL        string
+		chart          string
+		targetRevision string
+		expectedError  string
+	}{
+		{
+			repoURL:        "https://charts.test.io",
+			chart:          "test-chart",
+			targetRevision: "1.0.0",
+			expectedError:  "",
+		},
+		{
+			repoURL:        "",
+			chart:          "test-chart",
+			targetRevision: "1.0.0",
+			expectedError:  "'repoURL' parameter is empty",
+		},
+		{
+			repoURL:        "https://charts.test.io",
+			chart:          "",
+			targetRevision: "1.0.0",
+			expectedError:  "'chart' parameter is empty",
+		},
+		{
+			repoURL:        "https://charts.test.io",
+			chart:          "test-chart",
+			targetRevision: "",
+			expectedError:  "'targetRevision' parameter is empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		applicationBuilder := buildValidApplicationBuilder(buildApplicationTestClientWithDummyObject()).
+			WithHelmChart(testCase.repoURL, testCase.chart, testCase.targetRevision)
+		assert.Equal(t, testCase.expectedError, applicationBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.repoURL, applicationBuilder.Definition.Spec.Source.RepoURL)
+			assert.Equal(t, testCase.chart, applicationBuilder.Definition.Spec.Source.Chart)
+			assert.Equal(t, testCase.targetRevision, applicationBuilder.Definition.Spec.Source.TargetRevision)
+		}
+	}
+}
+
+func TestApplicationWithDirectory(t *testing.T) {
+	testCases := []struct {
+		recurse bool
+		include string
+		exclude string
+	}{
+		{
+			recurse: true,
+			include: "*.yaml",
+			exclude: "config.yaml",
+		},
+		{
+			recurse: false,
+			include: "",
+			exclude: "",
+		},
+	}
+
+	for _, testCase := range testCases {
+		applicationBuilder := buildValidApplicationBuilder(buildApplicationTestClientWithDummyObject()).
+			WithGitDetails("http://test.git", "main", "./dir/www/repo").
+			WithDirectory(testCase.recurse, testCase.include, testCase.exclude)
+		assert.Empty(t, applicationBuilder.errorMsg)
+
+		assert.Equal(t, "http://test.git", applicationBuilder.Definition.Spec.Source.RepoURL)
+		assert.Equal(t, "./dir/www/repo", applicationBuilder.Definition.Spec.Source.Path)
+		assert.Equal(t, &argocdtypes.ApplicationSourceDirectory{
+			Recurse: testCase.recurse,
+			Include: testCase.include,
+			Exclude: testCase.exclude,
+		}, applicationBuilder.Definition.Spec.Source.Directory)
+	}
+}
+
+func TestApplicationWithCascadeDeletion(t *testing.T) {
+	testCases := []struct {
+		finalizers         []string
+		expectedFinalizers []string
+	}{
+		{
+			finalizers:         nil,
+			expectedFinalizers: []string{ResourcesFinalizerName},
+		},
+		{
+			finalizers:         []string{"test-finalizer"},
+			expectedFinalizers: []string{"test-finalizer", ResourcesFinalizerName},
+		},
+		{
+			finalizers:         []string{ResourcesFinalizerName},
+			expectedFinalizers: []string{ResourcesFinalizerName},
+		},
+	}
+
+	for _, testCase := range testCases {
+		applicationBuilder := buildValidApplicationBuilder(buildApplicationTestClientWithDummyObject())
+		applicationBuilder.Definition.Finalizers = testCase.finalizers
+
+		applicationBuilder = applicationBuilder.WithCascadeDeletion()
+		assert.Empty(t, applicationBuilder.errorMsg)
+		assert.Equal(t, testCase.expectedFinalizers, applicationBuilder.Definition.Finalizers)
+	}
+}
+
+func TestApplicationWithoutCascadeDeletion(t *testing.T) {
+	testCases := []struct {
+		finalizers         []string
+		expectedFinalizers []string
+	}{
+		{
+			finalizers:         []string{ResourcesFinalizerName},
+			expectedFinalizers: []string{},
+		},
+		{
+			finalizers:         []string{"test-finalizer", ResourcesFinalizerName},
+			expectedFinalizers: []string{"test-finalizer"},
+		},
+		{
+			finalizers:         nil,
+			expectedFinalizers: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		applicationBuilder := buildValidApplicationBuilder(buildApplicationTestClientWithDummyObject())
+		applicationBuilder.Definition.Finalizers = testCase.finalizers
+
+		applicationBuilder = applicationBuilder.WithoutCascadeDeletion()
+		assert.Empty(t, applicationBuilder.errorMsg)
+		assert.Equal(t, testCase.expectedFinalizers, applicationBuilder.Definition.Finalizers)
+		assert.NotContains(t, applicationBuilder.Definition.Finalizers, ResourcesFinalizerName)
+	}
+}
+
+func TestApplicationGetManagedResources(t *testing.T) {
+	managedResources := []argocdtypes.ResourceStatus{
+		{
+			Version:   "v1",
+			Kind:      "ConfigMap",
+			Namespace: "test-namespace",
+			Name:      "test-configmap",
+			Status:    argocdtypes.SyncStatusCode("Synced"),
+		},
+		{
+			Group:     "apps",
+			Version:   "v1",
+			Kind:      "Deployment",
+			Namespace: "test-namespace",
+			Name:      "test-deployment",
+			Status:    argocdtypes.SyncStatusCode("OutOfSync"),
+		},
+	}
+
+	testCases := []struct {
+		testApplicationBuilder *ApplicationBuilder
+		expectedResources      []argocdtypes.ResourceStatus
+		expectedError          error
+	}{
+		{
+			testApplicationBuilder: buildValidApplicationBuilder(
+				buildApplicationTestClientWithResources(managedResources)),
+			expectedResources: managedResources,
+			expectedError:     nil,
+		},
+		{
+			testApplicationBuilder: buildValidApplicationBuilder(buildApplicationTestClientWithDummyObject()),
+			expectedResources:      nil,
+			expectedError:          nil,
+		},
+		{
+			testApplicationBuilder: buildValidApplicationBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: fmt.Errorf("application object %s does not exist in namespace %s",
+				defaultApplicationName, defaultApplicationNsName),
+		},
+	}
+
+	for _, testCase := range testCases {
+		resources, err := testCase.testApplicationBuilder.GetManagedResources()
+		assert.Equal(t, testCase.expectedError, err)
+		assert.Equal(t, testCase.expectedResources, resources)
+	}
+}
+
 func TestApplicationGVR(t *testing.T) {
 	assert.Equal(t, GetApplicationsGVR(),
 		schema.GroupVersionResource{
@@ -317,6 +580,16 @@ func buildApplicationTestClientWithDummyObject() *clients.Settings {
 	})
 }
 
+func buildApplicationTestClientWithResources(resources []argocdtypes.ResourceStatus) *clients.Settings {
+	application := buildDummyApplication(defaultApplicationName, defaultApplicationNsName)
+	application.Status.Resources = resources
+
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: []runtime.Object{application},
+		GVK:            []schema.GroupVersionKind{applicationGVK},
+	})
+}
+
 func buildDummyApplicationRuntime() []runtime.Object {
 	return append([]runtime.Object{}, &argocdtypes.Application{
 		ObjectMeta: metav1.ObjectMeta{
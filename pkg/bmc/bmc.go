@@ -3,14 +3,25 @@ package bmc
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/retry"
 	"github.com/stmcginnis/gofish"
+	"github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	"k8s.io/apimachinery/pkg/util/wait"
 )
 
@@ -24,6 +35,14 @@ const (
 )
 
 var (
+	// powerOnPollInterval is the interval between checks of the system's state while waiting for the OS to run or for
+	// a power state to be reached.
+	powerOnPollInterval = 5 * time.Second
+
+	// powerOnSettleTime is how long the power state must stay On before the OS is considered running when the system's
+	// BootProgress does not reach OSRunning.
+	powerOnSettleTime = time.Minute
+
 	// DefaultTimeOuts holds the default redfish and ssh timeouts.
 	DefaultTimeOuts = TimeOuts{
 		Redfish: defaultTimeOut,
@@ -35,6 +54,13 @@ var (
 		manufacturerHPE:  "VSP",
 		manufacturerDell: "console com2",
 	}
+
+	// shareTransferProtocols maps the supported virtual media share URL schemes to their Redfish transfer protocol.
+	shareTransferProtocols = map[string]redfish.TransferProtocolType{
+		"nfs":  redfish.NFSTransferProtocolType,
+		"cifs": redfish.CIFSTransferProtocolType,
+		"smb":  redfish.CIFSTransferProtocolType,
+	}
 )
 
 // User holds the Name and Password for a user (ssh/redfish).
@@ -53,19 +79,62 @@ type TimeOuts struct {
 	SSH time.Duration
 }
 
+// BootProgressTypes is the last boot progress state reported by a system. The vendored gofish does not expose
+// BootProgress, so its values are defined here.
+type BootProgressTypes string
+
+const (
+	// NoneBootProgressTypes means the system is not booting.
+	NoneBootProgressTypes BootProgressTypes = "None"
+	// PrimaryProcessorInitializationStartedBootProgressTypes means the system has started to initialize the primary
+	// processor.
+	PrimaryProcessorInitializationStartedBootProgressTypes BootProgressTypes = "PrimaryProcessorInitializationStarted"
+	// BusInitializationStartedBootProgressTypes means the system has started to initialize the buses.
+	BusInitializationStartedBootProgressTypes BootProgressTypes = "BusInitializationStarted"
+	// MemoryInitializationStartedBootProgressTypes means the system has started to initialize the memory.
+	MemoryInitializationStartedBootProgressTypes BootProgressTypes = "MemoryInitializationStarted"
+	// SecondaryProcessorInitializationStartedBootProgressTypes means the system has started to initialize the
+	// secondary processors.
+	SecondaryProcessorInitializationStartedBootProgressTypes BootProgressTypes = "SecondaryProcessorInitializationStarted"
+	// PCIResourceConfigStartedBootProgressTypes means the system has started to initialize the PCI resources.
+	PCIResourceConfigStartedBootProgressTypes BootProgressTypes = "PCIResourceConfigStarted"
+	// SystemHardwareInitializationCompleteBootProgressTypes means the system has completed initializing all hardware.
+	SystemHardwareInitializationCompleteBootProgressTypes BootProgressTypes = "SystemHardwareInitializationComplete"
+	// SetupEnteredBootProgressTypes means the system has entered the setup utility.
+	SetupEnteredBootProgressTypes BootProgressTypes = "SetupEntered"
+	// OSBootStartedBootProgressTypes means the OS has started to boot.
+	OSBootStartedBootProgressTypes BootProgressTypes = "OSBootStarted"
+	// OSRunningBootProgressTypes means the OS is running.
+	OSRunningBootProgressTypes BootProgressTypes = "OSRunning"
+	// OEMBootProgressTypes means an OEM-defined boot progress state.
+	OEMBootProgressTypes BootProgressTypes = "OEM"
+)
+
 // BMC is the holder struct for BMC access through redfish & ssh.
 type BMC struct {
 	host        string
 	redfishUser *User
 	sshUser     *User
+	sshSigner   ssh.Signer
 	sshPort     uint16
 	timeOuts    TimeOuts
 
+	sshHostKeyCallback ssh.HostKeyCallback
+	redfishTLSConfig   *tls.Config
+
+	redfishConnectRetries int
+	redfishRetryInterval  time.Duration
+
 	systemIndex       int
 	powerControlIndex int
 
 	sshSessionForSerialConsole *ssh.Session
 
+	// redfishMutex guards the cached Redfish session, which is only set between calls to Connect and Disconnect.
+	redfishMutex  sync.Mutex
+	redfishClient *gofish.APIClient
+	redfishCancel context.CancelFunc
+
 	errorMsg string
 }
 
@@ -145,6 +214,36 @@ func (bmc *BMC) WithRedfishTimeout(timeout time.Duration) *BMC {
 	return bmc
 }
 
+// WithRedfishConnectRetries provides the number of times to retry connecting to the Redfish API after a failed
+// attempt, waiting interval before the first retry and doubling the wait after each one. Rejected credentials are not
+// retried. By default, a single attempt is made.
+func (bmc *BMC) WithRedfishConnectRetries(retries int, interval time.Duration) *BMC {
+	if valid, _ := bmc.validate(); !valid {
+		return bmc
+	}
+
+	if retries < 0 {
+		glog.V(100).Infof("The Redfish connect retries %d is negative", retries)
+
+		bmc.errorMsg = "redfish connect 'retries' cannot be negative"
+
+		return bmc
+	}
+
+	if interval <= 0 {
+		glog.V(100).Infof("The Redfish retry interval %s is less than or equal to zero", interval)
+
+		bmc.errorMsg = "redfish retry 'interval' cannot be less than or equal to zero"
+
+		return bmc
+	}
+
+	bmc.redfishConnectRetries = retries
+	bmc.redfishRetryInterval = interval
+
+	return bmc
+}
+
 // WithRedfishSystemIndex provies the index of the system to use in the Redfish API. Note that the order of the systems
 // is nondeterministic.
 func (bmc *BMC) WithRedfishSystemIndex(index int) *BMC {
@@ -214,6 +313,60 @@ func (bmc *BMC) WithSSHUser(username, password string) *BMC {
 		Name:     username,
 		Password: password,
 	}
+	bmc.sshSigner = nil
+
+	return bmc
+}
+
+// WithSSHPrivateKey provides a private key to use when connecting to the BMC over SSH instead of a password. Neither
+// the username nor the key should be empty. The passphrase is only used if the key is encrypted and may be empty
+// otherwise. This replaces any user previously set by WithSSHUser, and calling WithSSHUser afterwards replaces the key.
+func (bmc *BMC) WithSSHPrivateKey(username string, privateKey []byte, passphrase string) *BMC {
+	if valid, _ := bmc.validate(); !valid {
+		return bmc
+	}
+
+	glog.V(100).Infof("Setting BMC SSH username to %s with private key authentication", username)
+
+	if username == "" {
+		glog.V(100).Info("The SSH username is empty")
+
+		bmc.errorMsg = "ssh 'username' cannot be empty"
+
+		return bmc
+	}
+
+	if len(privateKey) == 0 {
+		glog.V(100).Info("The SSH private key is empty")
+
+		bmc.errorMsg = "ssh 'privateKey' cannot be empty"
+
+		return bmc
+	}
+
+	var (
+		signer ssh.Signer
+		err    error
+	)
+
+	if passphrase == "" {
+		signer, err = ssh.ParsePrivateKey(privateKey)
+	} else {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(privateKey, []byte(passphrase))
+	}
+
+	if err != nil {
+		glog.V(100).Infof("Failed to parse the SSH private key: %v", err)
+
+		bmc.errorMsg = fmt.Sprintf("failed to parse ssh 'privateKey': %v", err)
+
+		return bmc
+	}
+
+	bmc.sshUser = &User{
+		Name: username,
+	}
+	bmc.sshSigner = signer
 
 	return bmc
 }
@@ -258,29 +411,283 @@ func (bmc *BMC) WithSSHTimeout(timeout time.Duration) *BMC {
 	return bmc
 }
 
+// Connect opens a Redfish session that is reused by subsequent Redfish methods until Disconnect is called, avoiding
+// the cost of logging in for every request. Logging in is bounded by the Redfish timeout, as is each use of the cached
+// session, which also respects the context of methods taking one. Calling Connect while already connected is a no-op.
+func (bmc *BMC) Connect() error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	bmc.redfishMutex.Lock()
+	defer bmc.redfishMutex.Unlock()
+
+	if bmc.redfishClient != nil {
+		glog.V(100).Infof("Redfish session to %s is already open", bmc.host)
+
+		return nil
+	}
+
+	glog.V(100).Infof("Opening reusable redfish session to %s", bmc.host)
+
+	// The session context must outlive the login, so the login is bounded by cancelling it after the Redfish timeout
+	// rather than by a deadline.
+	ctx, cancel := context.WithCancel(context.Background())
+	loginTimer := time.AfterFunc(bmc.timeOuts.Redfish, cancel)
+
+	redfishClient, sessionCancel, err := bmc.redfishConnectWithRetries(ctx, 0)
+	if err == nil && !loginTimer.Stop() {
+		sessionCancel()
+
+		err = context.DeadlineExceeded
+	}
+
+	if err != nil {
+		cancel()
+
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	bmc.redfishClient = redfishClient
+	bmc.redfishCancel = func() {
+		sessionCancel()
+		cancel()
+	}
+
+	return nil
+}
+
+// Disconnect logs out of the Redfish session opened by Connect, after which Redfish methods go back to opening a
+// session per call. Calling Disconnect while not connected is a no-op.
+func (bmc *BMC) Disconnect() error {
+	if valid, err := bmc.validate(); !valid {
+		return err
+	}
+
+	bmc.redfishMutex.Lock()
+	defer bmc.redfishMutex.Unlock()
+
+	if bmc.redfishClient == nil {
+		glog.V(100).Infof("Redfish session to %s is not open", bmc.host)
+
+		return nil
+	}
+
+	glog.V(100).Infof("Closing reusable redfish session to %s", bmc.host)
+
+	bmc.redfishClient.Logout()
+	bmc.redfishCancel()
+
+	bmc.redfishClient = nil
+	bmc.redfishCancel = nil
+
+	return nil
+}
+
+// VerifyRedfishCredentials checks that the configured Redfish user is able to log in to the BMC's Redfish API without
+// performing any other operation. Rejected credentials return an error distinct from connectivity errors.
+func (bmc *BMC) VerifyRedfishCredentials() error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Verifying redfish credentials for user %s", bmc.redfishUser.Name)
+
+	redfishClient, cancel, err := bmc.redfishConnectWithRetries(context.Background(), bmc.timeOuts.Redfish)
+	if err != nil {
+		var redfishError *common.Error
+		if errors.As(err, &redfishError) && redfishError.HTTPReturnedStatusCode == http.StatusUnauthorized {
+			glog.V(100).Infof("Redfish credentials for user %s are invalid: %v", bmc.redfishUser.Name, err)
+
+			return fmt.Errorf("invalid redfish credentials for user %s", bmc.redfishUser.Name)
+		}
+
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	redfishClient.Logout()
+	cancel()
+
+	return nil
+}
+
+// ChangeAccountPassword changes the password of the Redfish account matching username using the Redfish API. If
+// username is the configured Redfish user, the BMC is updated to use newPassword for subsequent requests.
+func (bmc *BMC) ChangeAccountPassword(username, newPassword string) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Changing password of account %s from bmc's redfish endpoint", username)
+
+	if username == "" {
+		glog.V(100).Info("The account username is empty")
+
+		return fmt.Errorf("account 'username' cannot be empty")
+	}
+
+	if newPassword == "" {
+		glog.V(100).Info("The account new password is empty")
+
+		return fmt.Errorf("account 'newPassword' cannot be empty")
+	}
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	account, err := redfishGetAccount(redfishClient, username)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish account %s: %v", username, err)
+
+		return fmt.Errorf("failed to get redfish account: %w", err)
+	}
+
+	account.Password = newPassword
+
+	err = account.Update()
+	if err != nil {
+		var redfishError *common.Error
+		if errors.As(err, &redfishError) && redfishError.HTTPReturnedStatusCode == http.StatusMethodNotAllowed {
+			glog.V(100).Infof("Redfish account %s is read-only: %v", username, err)
+
+			return fmt.Errorf("redfish account %s is read-only", username)
+		}
+
+		glog.V(100).Infof("Failed to change password of account %s: %v", username, err)
+
+		return fmt.Errorf("failed to change password of account %s: %w", username, err)
+	}
+
+	if bmc.redfishUser.Name == username {
+		bmc.redfishUser.Password = newPassword
+	}
+
+	return nil
+}
+
+// ListAccounts returns the accounts configured in the AccountService of the Redfish API, sorted by their ID. Each
+// account includes its username, role, and whether it is enabled. Passwords are always cleared.
+func (bmc *BMC) ListAccounts() ([]redfish.ManagerAccount, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Info("Listing accounts from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	accountService, err := redfishGetAccountService(redfishClient)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish account service: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish account service: %w", err)
+	}
+
+	accounts, err := accountService.Accounts()
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish accounts: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish accounts: %w", err)
+	}
+
+	// Accounts are fetched concurrently so they must be sorted to keep the order stable.
+	slices.SortFunc(accounts, func(a, b *redfish.ManagerAccount) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	var managerAccounts []redfish.ManagerAccount
+
+	for _, account := range accounts {
+		account.Password = ""
+		managerAccounts = append(managerAccounts, *account)
+	}
+
+	return managerAccounts, nil
+}
+
+// WithRedfishTLSConfig provides the TLS configuration used when connecting to the BMC's Redfish API, such as one with
+// RootCAs set to the BMC's CA, so that its certificate is verified. It should not be nil. When not set, certificates
+// are not verified.
+func (bmc *BMC) WithRedfishTLSConfig(tlsConfig *tls.Config) *BMC {
+	if valid, _ := bmc.validate(); !valid {
+		return bmc
+	}
+
+	glog.V(100).Info("Setting Redfish TLS config")
+
+	if tlsConfig == nil {
+		glog.V(100).Info("The Redfish TLS config is nil")
+
+		bmc.errorMsg = "redfish 'tlsConfig' cannot be nil"
+
+		return bmc
+	}
+
+	bmc.redfishTLSConfig = tlsConfig
+
+	return bmc
+}
+
+// WithSSHHostKeyCallback provides the callback used to verify the BMC's host key when connecting over SSH, such as one
+// created by knownhosts.New. It should not be nil. When not set, host keys are not verified.
+func (bmc *BMC) WithSSHHostKeyCallback(hostKeyCallback ssh.HostKeyCallback) *BMC {
+	if valid, _ := bmc.validate(); !valid {
+		return bmc
+	}
+
+	glog.V(100).Info("Setting SSH host key callback")
+
+	if hostKeyCallback == nil {
+		glog.V(100).Info("The SSH host key callback is nil")
+
+		bmc.errorMsg = "ssh 'hostKeyCallback' cannot be nil"
+
+		return bmc
+	}
+
+	bmc.sshHostKeyCallback = hostKeyCallback
+
+	return bmc
+}
+
 // SystemManufacturer gets system's manufacturer from the BMC's RedFish API endpoint.
 func (bmc *BMC) SystemManufacturer() (string, error) {
+	return bmc.SystemManufacturerContext(context.Background())
+}
+
+// SystemManufacturerContext is like SystemManufacturer but uses ctx as the parent of the Redfish session context.
+func (bmc *BMC) SystemManufacturerContext(ctx context.Context) (string, error) {
 	if valid, err := bmc.validateRedfish(); !valid {
 		return "", err
 	}
 
 	glog.V(100).Infof("Getting SystemManufacturer param from bmc's redfish endpoint")
 
-	redfishClient, cancel, err := redfishConnect(
-		bmc.host,
-		bmc.redfishUser.Name,
-		bmc.redfishUser.Password,
-		bmc.timeOuts.Redfish)
+	redfishClient, release, err := bmc.redfishSession(ctx)
 	if err != nil {
 		glog.V(100).Infof("Redfish connection error: %v", err)
 
 		return "", fmt.Errorf("redfish connection error: %w", err)
 	}
 
-	defer func() {
-		redfishClient.Logout()
-		cancel()
-	}()
+	defer release()
 
 	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
 	if err != nil {
@@ -294,27 +701,25 @@ func (bmc *BMC) SystemManufacturer() (string, error) {
 
 // IsSecureBootEnabled returns whether the SecureBoot feature is enabled using the BMC's RedFish API endpoint.
 func (bmc *BMC) IsSecureBootEnabled() (bool, error) {
+	return bmc.IsSecureBootEnabledContext(context.Background())
+}
+
+// IsSecureBootEnabledContext is like IsSecureBootEnabled but uses ctx as the parent of the Redfish session context.
+func (bmc *BMC) IsSecureBootEnabledContext(ctx context.Context) (bool, error) {
 	if valid, err := bmc.validateRedfish(); !valid {
 		return false, err
 	}
 
 	glog.V(100).Infof("Getting secure boot status from bmc's redfish endpoint")
 
-	redfishClient, cancel, err := redfishConnect(
-		bmc.host,
-		bmc.redfishUser.Name,
-		bmc.redfishUser.Password,
-		bmc.timeOuts.Redfish)
+	redfishClient, release, err := bmc.redfishSession(ctx)
 	if err != nil {
 		glog.V(100).Infof("Redfish connection error: %v", err)
 
 		return false, fmt.Errorf("redfish connection error: %w", err)
 	}
 
-	defer func() {
-		redfishClient.Logout()
-		cancel()
-	}()
+	defer release()
 
 	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemIndex)
 	if err != nil {
@@ -328,27 +733,25 @@ func (bmc *BMC) IsSecureBootEnabled() (bool, error) {
 
 // SecureBootEnable enables the SecureBoot feature using the BMC's RedFish API endpoint.
 func (bmc *BMC) SecureBootEnable() error {
+	return bmc.SecureBootEnableContext(context.Background())
+}
+
+// SecureBootEnableContext is like SecureBootEnable but uses ctx as the parent of the Redfish session context.
+func (bmc *BMC) SecureBootEnableContext(ctx context.Context) error {
 	if valid, err := bmc.validateRedfish(); !valid {
 		return err
 	}
 
 	glog.V(100).Infof("Enabling secure boot from bmc's redfish endpoint")
 
-	redfishClient, cancel, err := redfishConnect(
-		bmc.host,
-		bmc.redfishUser.Name,
-		bmc.redfishUser.Password,
-		bmc.timeOuts.Redfish)
+	redfishClient, release, err := bmc.redfishSession(ctx)
 	if err != nil {
 		glog.V(100).Infof("Redfish connection error: %v", err)
 
 		return fmt.Errorf("redfish connection error: %w", err)
 	}
 
-	defer func() {
-		redfishClient.Logout()
-		cancel()
-	}()
+	defer release()
 
 	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemIndex)
 	if err != nil {
@@ -377,27 +780,25 @@ func (bmc *BMC) SecureBootEnable() error {
 
 // SecureBootDisable disables the SecureBoot feature using the BMC's RedFish API endpoint.
 func (bmc *BMC) SecureBootDisable() error {
+	return bmc.SecureBootDisableContext(context.Background())
+}
+
+// SecureBootDisableContext is like SecureBootDisable but uses ctx as the parent of the Redfish session context.
+func (bmc *BMC) SecureBootDisableContext(ctx context.Context) error {
 	if valid, err := bmc.validateRedfish(); !valid {
 		return err
 	}
 
 	glog.V(100).Infof("Disabling secure boot from bmc's redfish endpoint")
 
-	redfishClient, cancel, err := redfishConnect(
-		bmc.host,
-		bmc.redfishUser.Name,
-		bmc.redfishUser.Password,
-		bmc.timeOuts.Redfish)
+	redfishClient, release, err := bmc.redfishSession(ctx)
 	if err != nil {
 		glog.V(100).Infof("Redfish connection error: %v", err)
 
 		return fmt.Errorf("redfish connection error: %w", err)
 	}
 
-	defer func() {
-		redfishClient.Logout()
-		cancel()
-	}()
+	defer release()
 
 	sboot, err := redfishGetSystemSecureBoot(redfishClient, bmc.systemIndex)
 	if err != nil {
@@ -426,27 +827,25 @@ func (bmc *BMC) SecureBootDisable() error {
 
 // SystemResetAction performs the specified reset action against the system.
 func (bmc *BMC) SystemResetAction(action redfish.ResetType) error {
+	return bmc.SystemResetActionContext(context.Background(), action)
+}
+
+// SystemResetActionContext is like SystemResetAction but uses ctx as the parent of the Redfish session context.
+func (bmc *BMC) SystemResetActionContext(ctx context.Context, action redfish.ResetType) error {
 	if valid, err := bmc.validateRedfish(); !valid {
 		return err
 	}
 
 	glog.V(100).Infof("Performing reset action %v from the bmc's redfish endpoint", action)
 
-	redfishClient, cancel, err := redfishConnect(
-		bmc.host,
-		bmc.redfishUser.Name,
-		bmc.redfishUser.Password,
-		bmc.timeOuts.Redfish)
+	redfishClient, release, err := bmc.redfishSession(ctx)
 	if err != nil {
 		glog.V(100).Infof("Redfish connection error: %v", err)
 
 		return fmt.Errorf("redfish connection error: %w", err)
 	}
 
-	defer func() {
-		redfishClient.Logout()
-		cancel()
-	}()
+	defer release()
 
 	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
 	if err != nil {
@@ -473,7 +872,9 @@ func (bmc *BMC) SystemPowerOn() error {
 	return bmc.SystemResetAction(redfish.OnResetType)
 }
 
-// SystemPowerOff performs a non-graceful power off of the system using the Redfish API.
+// SystemPowerOff performs a non-graceful power off of the system using the Redfish API. Unlike SystemGracefulShutdown,
+// which asks the OS to shut down and may be ignored by an unresponsive OS, power is cut immediately using the ForceOff
+// reset type.
 func (bmc *BMC) SystemPowerOff() error {
 	return bmc.SystemResetAction(redfish.ForceOffResetType)
 }
@@ -551,127 +952,1345 @@ func (bmc *BMC) SystemPowerCycle() error {
 	return bmc.SystemPowerOn()
 }
 
-// SystemPowerState returns the system's current power state using the Redfish API.
-// Returned string can be one of On/Off/Paused/PoweringOn/PoweringOff.
-func (bmc *BMC) SystemPowerState() (string, error) {
+// GracefulRebootAndWait gracefully reboots the system using the Redfish API and waits up to timeout for it to go down
+// and come back up. When the system reports BootProgress and supports the GracefulRestart reset type, it is considered
+// down once its LastState changes from the one before the reset and up once it returns to that state or OSRunning, so
+// warm reboots that keep the system powered on are detected. Otherwise, GracefulShutdown and On reset actions are
+// performed so that the system is seen to be powered off. If only GracefulRestart is supported and BootProgress is not
+// reported, the system must be seen with a power state other than On, so the context deadline error is returned for
+// warm reboots.
+//
+//nolint:funlen
+func (bmc *BMC) GracefulRebootAndWait(timeout time.Duration) error {
 	if valid, err := bmc.validateRedfish(); !valid {
-		return "", err
+		return err
 	}
 
-	glog.V(100).Info("Collecting current power state from bmc's redfish endpoint")
+	glog.V(100).Infof("Gracefully rebooting system and waiting up to %s for it to be powered on", timeout)
 
-	redfishClient, cancel, err := redfishConnect(
-		bmc.host,
-		bmc.redfishUser.Name,
-		bmc.redfishUser.Password,
-		bmc.timeOuts.Redfish)
+	supportedResetTypes, err := bmc.getSupportedResetTypes()
 	if err != nil {
-		glog.V(100).Infof("Redfish connection error: %v", err)
+		glog.V(100).Infof("Failed to get system's supported reset types: %v", err)
 
-		return "", fmt.Errorf("redfish connection error: %w", err)
+		return fmt.Errorf("failed to get system's supported reset types: %w", err)
 	}
 
-	defer func() {
-		redfishClient.Logout()
-		cancel()
-	}()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	_, initialBootProgress, err := bmc.getPowerStateAndBootProgress(ctx)
 	if err != nil {
-		glog.V(100).Infof("Failed to get redfish system: %v", err)
+		glog.V(100).Infof("Failed to get system's initial boot progress: %v", err)
 
-		return "", fmt.Errorf("failed to get redfish system: %w", err)
+		return fmt.Errorf("failed to get system's initial boot progress: %w", err)
 	}
 
-	return string(system.PowerState), nil
-}
+	gracefulRestartSupported := isResetTypeSupported(redfish.GracefulRestartResetType, supportedResetTypes)
 
-// PowerUsage returns the current power usage of the chassis in watts using the Redfish API. This method uses the first
-// chassis with a power link and the power control index for the BMC client.
-func (bmc *BMC) PowerUsage() (float32, error) {
+	switch {
+	case gracefulRestartSupported && initialBootProgress != "":
+		err = bmc.SystemResetActionContext(ctx, redfish.GracefulRestartResetType)
+		if err != nil {
+			glog.V(100).Infof("Failed to perform GracefulRestart system reset: %v", err)
+
+			return fmt.Errorf("failed to perform GracefulRestart system reset: %w", err)
+		}
+
+		return bmc.waitForRestart(ctx, initialBootProgress)
+	case isResetTypeSupported(redfish.GracefulShutdownResetType, supportedResetTypes) &&
+		isResetTypeSupported(redfish.OnResetType, supportedResetTypes):
+		glog.V(100).Infof("Performing GracefulShutdown and On reset actions to reboot the system")
+
+		err = bmc.SystemResetActionContext(ctx, redfish.GracefulShutdownResetType)
+		if err != nil {
+			glog.V(100).Infof("Failed to perform GracefulShutdown system reset: %v", err)
+
+			return fmt.Errorf("failed to perform GracefulShutdown system reset: %w", err)
+		}
+
+		err = bmc.waitForPowerState(ctx, func(powerState redfish.PowerState) bool {
+			return powerState == redfish.OffPowerState
+		})
+		if err != nil {
+			glog.V(100).Infof("Failure waiting for system's power state to be %v: %v", redfish.OffPowerState, err)
+
+			return fmt.Errorf("failure waiting for system's power state to be %v: %w", redfish.OffPowerState, err)
+		}
+
+		err = bmc.SystemResetActionContext(ctx, redfish.OnResetType)
+		if err != nil {
+			glog.V(100).Infof("Failed to perform On system reset: %v", err)
+
+			return fmt.Errorf("failed to perform On system reset: %w", err)
+		}
+	case gracefulRestartSupported:
+		glog.V(100).Infof("BootProgress not reported and GracefulShutdown not supported. Relying on the power state")
+
+		err = bmc.SystemResetActionContext(ctx, redfish.GracefulRestartResetType)
+		if err != nil {
+			glog.V(100).Infof("Failed to perform GracefulRestart system reset: %v", err)
+
+			return fmt.Errorf("failed to perform GracefulRestart system reset: %w", err)
+		}
+
+		err = bmc.waitForPowerState(ctx, func(powerState redfish.PowerState) bool {
+			return powerState != redfish.OnPowerState
+		})
+		if err != nil {
+			glog.V(100).Infof("Failure waiting for system to go down: %v", err)
+
+			return fmt.Errorf("failure waiting for system to go down: %w", err)
+		}
+	default:
+		glog.V(100).Infof("Unable to perform graceful reboot (supported reset types: %v)", supportedResetTypes)
+
+		return fmt.Errorf("unable to perform graceful reboot (supported reset types: %v)", supportedResetTypes)
+	}
+
+	err = bmc.waitForSystemState(ctx, func(powerState redfish.PowerState, bootProgress BootProgressTypes) bool {
+		if bootProgress != "" && bootProgress != OSRunningBootProgressTypes && bootProgress != initialBootProgress {
+			return false
+		}
+
+		return powerState == redfish.OnPowerState
+	})
+	if err != nil {
+		glog.V(100).Infof("Failure waiting for system to come back up: %v", err)
+
+		return fmt.Errorf("failure waiting for system to come back up: %w", err)
+	}
+
+	return nil
+}
+
+// ManagerReset performs the specified reset action against the first manager, which is the BMC itself, using the
+// Redfish API. Unlike SystemResetAction, the host system is not reset. The BMC is unreachable until it has restarted.
+func (bmc *BMC) ManagerReset(resetType redfish.ResetType) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Performing manager reset action %v from the bmc's redfish endpoint", resetType)
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	manager, err := redfishGetManager(redfishClient)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish manager: %v", err)
+
+		return fmt.Errorf("failed to get redfish manager: %w", err)
+	}
+
+	err = manager.Reset(resetType)
+	if err != nil {
+		glog.V(100).Infof("Failed to perform manager reset action %v: %v", resetType, err)
+
+		return fmt.Errorf("failed to perform manager reset action %v: %w", resetType, err)
+	}
+
+	return nil
+}
+
+// ManagerGracefulRestart performs a graceful restart of the BMC using the Redfish API.
+func (bmc *BMC) ManagerGracefulRestart() error {
+	return bmc.ManagerReset(redfish.GracefulRestartResetType)
+}
+
+// ManagerForceRestart performs a (non-graceful) forced restart of the BMC using the Redfish API.
+func (bmc *BMC) ManagerForceRestart() error {
+	return bmc.ManagerReset(redfish.ForceRestartResetType)
+}
+
+// ManagerFirmwareVersion returns the firmware version of the manager, which is the BMC itself, responsible for the
+// system using the Redfish API. Unlike FirmwareInventory, only the BMC's own firmware is reported.
+func (bmc *BMC) ManagerFirmwareVersion() (string, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Info("Getting manager firmware version from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return "", fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	manager, err := redfishGetSystemManager(redfishClient, system)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish manager of system %s: %v", system.ID, err)
+
+		return "", fmt.Errorf("failed to get redfish manager of system %s: %w", system.ID, err)
+	}
+
+	return manager.FirmwareVersion, nil
+}
+
+// PowerOnAndWaitForOS powers on the system using the Redfish API and waits up to timeout for the OS to be running. The
+// OS is considered running once the system's BootProgress reaches OSRunning or, for systems that do not report
+// BootProgress or stop updating it before OSRunning, once the power state has stayed On for a settle time of one
+// minute.
+func (bmc *BMC) PowerOnAndWaitForOS(timeout time.Duration) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Powering on system and waiting up to %s for the OS to be running", timeout)
+
+	err := bmc.SystemPowerOn()
+	if err != nil {
+		glog.V(100).Infof("Failed to power on system: %v", err)
+
+		return fmt.Errorf("failed to power on system: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var poweredOnSince time.Time
+
+	err = bmc.waitForSystemState(ctx, func(powerState redfish.PowerState, bootProgress BootProgressTypes) bool {
+		if bootProgress == OSRunningBootProgressTypes {
+			return true
+		}
+
+		if powerState != redfish.OnPowerState {
+			poweredOnSince = time.Time{}
+
+			return false
+		}
+
+		if poweredOnSince.IsZero() {
+			poweredOnSince = time.Now()
+		}
+
+		return time.Since(poweredOnSince) >= powerOnSettleTime
+	})
+	if err != nil {
+		glog.V(100).Infof("Failure waiting for the system's OS to be running: %v", err)
+
+		return fmt.Errorf("failure waiting for the system's OS to be running: %w", err)
+	}
+
+	return nil
+}
+
+// BootFromVirtualMedia inserts the image at imageURL as virtual CD media, sets a one-time CD boot source override, and
+// performs a forced system reset using the Redfish API. If timeout is greater than zero and the system reports
+// BootProgress, it then waits up to timeout for the system to restart, that is for its LastState to change and then
+// return to OSRunning or the state before the reset. Since the power state stays On during a forced restart, nothing is
+// waited for if BootProgress is not reported. If any step after inserting the media fails, it is ejected from the slot
+// it was inserted into.
+func (bmc *BMC) BootFromVirtualMedia(imageURL string, timeout time.Duration) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Booting system from virtual media image %s", imageURL)
+
+	err := bmc.InsertVirtualMedia(imageURL, redfish.CDMediaType)
+	if err != nil {
+		glog.V(100).Infof("Failed to insert virtual media: %v", err)
+
+		return fmt.Errorf("failed to insert virtual media: %w", err)
+	}
+
+	err = bmc.bootFromInsertedVirtualMedia(timeout)
+	if err != nil {
+		glog.V(100).Infof("Failed to boot from virtual media, ejecting it: %v", err)
+
+		if ejectErr := bmc.ejectVirtualMedia(redfish.CDMediaType); ejectErr != nil {
+			glog.V(100).Infof("Failed to eject virtual media: %v", ejectErr)
+
+			return fmt.Errorf("%w (failed to eject virtual media: %w)", err, ejectErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// WaitForPowerState waits up to timeout for the system to reach the target power state using the Redfish API. Errors
+// getting the power state are retried until the timeout elapses, in which case the context deadline error is returned.
+func (bmc *BMC) WaitForPowerState(target redfish.PowerState, timeout time.Duration) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting up to %s for system to be in power state %v", timeout, target)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return bmc.waitForPowerState(ctx, func(powerState redfish.PowerState) bool {
+		return powerState == target
+	})
+}
+
+// GetBootProgress returns the last boot progress state of the system using the Redfish API. Older Redfish
+// implementations that do not report BootProgress return an empty value rather than an error.
+func (bmc *BMC) GetBootProgress() (BootProgressTypes, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Info("Collecting boot progress from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return "", fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	bootProgress, err := redfishGetBootProgress(redfishClient, system)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system's boot progress: %v", err)
+
+		return "", fmt.Errorf("failed to get boot progress: %w", err)
+	}
+
+	return bootProgress, nil
+}
+
+// SystemPowerState returns the system's current power state using the Redfish API.
+// Returned string can be one of On/Off/Paused/PoweringOn/PoweringOff.
+func (bmc *BMC) SystemPowerState() (string, error) {
+	powerState, err := bmc.GetPowerState()
+
+	return string(powerState), err
+}
+
+// GetPowerState returns the system's current power state using the Redfish API. Unlike SystemPowerState, the state is
+// returned as a redfish.PowerState so it can be compared directly against the redfish constants.
+func (bmc *BMC) GetPowerState() (redfish.PowerState, error) {
+	return bmc.GetPowerStateContext(context.Background())
+}
+
+// GetPowerStateContext is like GetPowerState but uses ctx as the parent of the Redfish session context.
+func (bmc *BMC) GetPowerStateContext(ctx context.Context) (redfish.PowerState, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Info("Collecting current power state from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(ctx)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return "", fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	return system.PowerState, nil
+}
+
+// GetSystemHealth returns the health rollup and state of the system using the Redfish API. The health rollup covers
+// the system and all of its dependent resources, so it can be used to gate operations on overall hardware health.
+func (bmc *BMC) GetSystemHealth() (common.Health, common.State, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", "", err
+	}
+
+	glog.V(100).Info("Collecting system health from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return "", "", fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	return system.Status.HealthRollup, system.Status.State, nil
+}
+
+// SystemSummary returns the processor and memory summaries of the system using the Redfish API. These include the
+// processor count and model as well as the total system memory, which can be used to check the hardware matches the
+// expected SKU.
+func (bmc *BMC) SystemSummary() (redfish.ProcessorSummary, redfish.MemorySummary, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return redfish.ProcessorSummary{}, redfish.MemorySummary{}, err
+	}
+
+	glog.V(100).Info("Collecting processor and memory summary from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return redfish.ProcessorSummary{}, redfish.MemorySummary{}, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return redfish.ProcessorSummary{}, redfish.MemorySummary{}, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	return system.ProcessorSummary, system.MemorySummary, nil
+}
+
+// Temperatures returns the temperature sensor readings of the first chassis with a thermal link using the Redfish API.
+func (bmc *BMC) Temperatures() ([]redfish.Temperature, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Info("Collecting temperature readings from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	thermal, err := redfishGetThermal(redfishClient)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish thermal: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish thermal: %w", err)
+	}
+
+	return thermal.Temperatures, nil
+}
+
+// FanSpeeds returns the fan readings of the first chassis with a thermal link using the Redfish API. Readings are
+// expressed in the units given by each fan's ReadingUnits.
+func (bmc *BMC) FanSpeeds() ([]redfish.Fan, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Info("Collecting fan speeds from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	thermal, err := redfishGetThermal(redfishClient)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish thermal: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish thermal: %w", err)
+	}
+
+	return thermal.Fans, nil
+}
+
+// GetIndicatorLED returns the state of the indicator LED of the first chassis exposing one using the Redfish API.
+func (bmc *BMC) GetIndicatorLED() (common.IndicatorLED, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Info("Getting indicator LED state from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	chassis, err := redfishGetIndicatorLEDChassis(redfishClient)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish chassis with indicator LED: %v", err)
+
+		return "", fmt.Errorf("failed to get redfish chassis with indicator LED: %w", err)
+	}
+
+	return chassis.IndicatorLED, nil
+}
+
+// SetIndicatorLED sets the state of the indicator LED of the first chassis exposing one using the Redfish API. The
+// state must be one of Lit, Blinking, or Off.
+func (bmc *BMC) SetIndicatorLED(state common.IndicatorLED) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Setting indicator LED state to %s from bmc's redfish endpoint", state)
+
+	validStates := []common.IndicatorLED{common.LitIndicatorLED, common.BlinkingIndicatorLED, common.OffIndicatorLED}
+	if !slices.Contains(validStates, state) {
+		glog.V(100).Infof("The indicator LED state %s is invalid", state)
+
+		return fmt.Errorf("invalid indicator LED state %q, must be one of %v", state, validStates)
+	}
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	chassis, err := redfishGetIndicatorLEDChassis(redfishClient)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish chassis with indicator LED: %v", err)
+
+		return fmt.Errorf("failed to get redfish chassis with indicator LED: %w", err)
+	}
+
+	chassis.IndicatorLED = state
+
+	err = chassis.Update()
+	if err != nil {
+		glog.V(100).Infof("Failed to set indicator LED state: %v", err)
+
+		return fmt.Errorf("failed to set indicator LED state: %w", err)
+	}
+
+	return nil
+}
+
+// GetChassisIntrusionStatus returns the state of the intrusion sensor of the first chassis exposing one using the
+// Redfish API. An error is returned if no chassis reports physical security.
+func (bmc *BMC) GetChassisIntrusionStatus() (redfish.IntrusionSensor, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", err
+	}
+
+	glog.V(100).Info("Getting chassis intrusion sensor state from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	chassis, err := redfishGetIntrusionSensorChassis(redfishClient)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish chassis with intrusion sensor: %v", err)
+
+		return "", fmt.Errorf("failed to get redfish chassis with intrusion sensor: %w", err)
+	}
+
+	return chassis.PhysicalSecurity.IntrusionSensor, nil
+}
+
+// GetSystemEventLog returns the entries of the System Event Log (SEL) using the Redfish API. The SEL log service is
+// looked up in the log services of the manager first and then in those of the system. Entries are sorted by their
+// creation time.
+func (bmc *BMC) GetSystemEventLog() ([]redfish.LogEntry, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Info("Collecting system event log from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	logService, err := redfishGetSELLogService(redfishClient, bmc.systemIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish SEL log service: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish SEL log service: %w", err)
+	}
+
+	entries, err := logService.Entries()
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish SEL log entries: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish SEL log entries: %w", err)
+	}
+
+	var logEntries []redfish.LogEntry
+
+	for _, entry := range entries {
+		logEntries = append(logEntries, *entry)
+	}
+
+	slices.SortFunc(logEntries, func(a, b redfish.LogEntry) int {
+		return strings.Compare(a.Created, b.Created)
+	})
+
+	return logEntries, nil
+}
+
+// ClearSystemEventLog clears the entries of the System Event Log (SEL) using the Redfish API. The SEL log service is
+// looked up the same way as in GetSystemEventLog.
+func (bmc *BMC) ClearSystemEventLog() error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Info("Clearing system event log from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	logService, err := redfishGetSELLogService(redfishClient, bmc.systemIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish SEL log service: %v", err)
+
+		return fmt.Errorf("failed to get redfish SEL log service: %w", err)
+	}
+
+	err = logService.ClearLog()
+	if err != nil {
+		glog.V(100).Infof("Failed to clear redfish SEL log: %v", err)
+
+		return fmt.Errorf("failed to clear redfish SEL log: %w", err)
+	}
+
+	return nil
+}
+
+// FirmwareInventory returns the firmware components, such as the BIOS and the BMC itself, reported by the firmware
+// inventory of the UpdateService using the Redfish API. Components are sorted by their ID.
+func (bmc *BMC) FirmwareInventory() ([]redfish.SoftwareInventory, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Info("Collecting firmware inventory from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	updateService, err := redfishClient.GetService().UpdateService()
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish update service: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish update service: %w", err)
+	}
+
+	inventories, err := updateService.FirmwareInventories()
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish firmware inventory: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish firmware inventory: %w", err)
+	}
+
+	var firmwareInventory []redfish.SoftwareInventory
+
+	for _, inventory := range inventories {
+		firmwareInventory = append(firmwareInventory, *inventory)
+	}
+
+	slices.SortFunc(firmwareInventory, func(a, b redfish.SoftwareInventory) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return firmwareInventory, nil
+}
+
+// StorageDrives returns the drives attached to all of the storage controllers of the system using the Redfish API.
+// Controllers are visited in order of their ID and the drives of each controller are returned in the order the
+// controller reports them.
+func (bmc *BMC) StorageDrives() ([]redfish.Drive, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Info("Collecting storage drives from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	storages, err := system.Storage()
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system storage: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish system storage: %w", err)
+	}
+
+	// Storage collections are fetched concurrently so they must be sorted to keep the drive order stable.
+	slices.SortFunc(storages, func(a, b *redfish.Storage) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	var storageDrives []redfish.Drive
+
+	for _, storage := range storages {
+		drives, err := storage.Drives()
+		if err != nil {
+			glog.V(100).Infof("Failed to get drives of redfish storage controller %s: %v", storage.ID, err)
+
+			return nil, fmt.Errorf("failed to get drives of redfish storage controller %s: %w", storage.ID, err)
+		}
+
+		for _, drive := range drives {
+			storageDrives = append(storageDrives, *drive)
+		}
+	}
+
+	return storageDrives, nil
+}
+
+// SetBootSourceOverride sets the boot source override target and enabled state of the system using the Redfish API.
+// For example, a one-time PXE boot can be requested with redfish.PxeBootSourceOverrideTarget and
+// redfish.OnceBootSourceOverrideEnabled.
+func (bmc *BMC) SetBootSourceOverride(
+	target redfish.BootSourceOverrideTarget, enabled redfish.BootSourceOverrideEnabled) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Setting boot source override to target %s with enabled %s from bmc's redfish endpoint",
+		target, enabled)
+
+	if target == "" {
+		glog.V(100).Info("The boot source override target is empty")
+
+		return fmt.Errorf("boot source override 'target' cannot be empty")
+	}
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	// Only the override fields are sent so that read-only boot properties, such as the boot order, are not patched.
+	err = system.SetBoot(redfish.Boot{
+		BootSourceOverrideTarget:  target,
+		BootSourceOverrideEnabled: enabled,
+	})
+	if err != nil {
+		glog.V(100).Infof("Failed to set boot source override: %v", err)
+
+		return fmt.Errorf("failed to set boot source override: %w", err)
+	}
+
+	system.Boot.BootSourceOverrideTarget = target
+	system.Boot.BootSourceOverrideEnabled = enabled
+
+	return nil
+}
+
+// PowerUsage returns the current power usage of the chassis in watts using the Redfish API. This method uses the first
+// chassis with a power link and the power control index for the BMC client.
+func (bmc *BMC) PowerUsage() (float32, error) {
+	return bmc.PowerUsageContext(context.Background())
+}
+
+// PowerUsageContext is like PowerUsage but uses ctx as the parent of the Redfish session context.
+func (bmc *BMC) PowerUsageContext(ctx context.Context) (float32, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return 0.0, err
+	}
+
+	glog.V(100).Info("Collecting current power usage from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(ctx)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return 0.0, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	powerControl, err := redfishGetPowerControl(redfishClient, bmc.powerControlIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish power control: %v", err)
+
+		return 0.0, fmt.Errorf("failed to get redfish power control: %w", err)
+	}
+
+	return powerControl.PowerConsumedWatts, nil
+}
+
+// SamplePowerUsage returns samples readings of the power usage of the chassis in watts, taken interval apart, using a
+// single Redfish session. Like PowerUsage, it uses the first chassis with a power link and the power control index for
+// the BMC client. If the session opened by Connect is used, other Redfish methods wait until sampling is done.
+func (bmc *BMC) SamplePowerUsage(samples int, interval time.Duration) ([]float32, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Sampling power usage %d times every %s from bmc's redfish endpoint", samples, interval)
+
+	if samples <= 0 {
+		glog.V(100).Info("The power usage samples is less than or equal to zero")
+
+		return nil, fmt.Errorf("power usage 'samples' cannot be less than or equal to zero")
+	}
+
+	if interval <= 0 {
+		glog.V(100).Info("The power usage sample interval is less than or equal to zero")
+
+		return nil, fmt.Errorf("power usage sample 'interval' cannot be less than or equal to zero")
+	}
+
+	// The session must outlast the waits between samples as well as the requests themselves.
+	sessionTimeout := bmc.timeOuts.Redfish + time.Duration(samples-1)*interval
+
+	redfishClient, release, err := bmc.redfishSessionWithTimeout(context.Background(), sessionTimeout)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	var powerUsages []float32
+
+	for sample := 0; sample < samples; sample++ {
+		if sample > 0 {
+			time.Sleep(interval)
+		}
+
+		powerControl, err := redfishGetPowerControl(redfishClient, bmc.powerControlIndex)
+		if err != nil {
+			glog.V(100).Infof("Failed to get redfish power control for sample %d: %v", sample, err)
+
+			return nil, fmt.Errorf("failed to get redfish power control for sample %d: %w", sample, err)
+		}
+
+		powerUsages = append(powerUsages, powerControl.PowerConsumedWatts)
+	}
+
+	return powerUsages, nil
+}
+
+// GetPowerLimit returns the power limit in watts of the chassis using the Redfish API, or nil if the chassis is not
+// power capped. This method uses the first chassis with a power link and the power control index for the BMC client.
+func (bmc *BMC) GetPowerLimit() (*int, error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Info("Collecting current power limit from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return nil, fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	powerControl, err := redfishGetPowerControl(redfishClient, bmc.powerControlIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish power control: %v", err)
+
+		return nil, fmt.Errorf("failed to get redfish power control: %w", err)
+	}
+
+	if powerControl.PowerLimit.LimitInWatts <= 0 {
+		return nil, nil //nolint:nilnil // A nil limit means the chassis is not power capped.
+	}
+
+	limit := int(powerControl.PowerLimit.LimitInWatts)
+
+	return &limit, nil
+}
+
+// SetPowerLimit sets the power limit in watts of the chassis using the Redfish API. A limit of zero removes the power
+// cap by clearing the limit, matching GetPowerLimit returning nil for it. This method uses the first chassis with a
+// power link and the power control index for the BMC client.
+func (bmc *BMC) SetPowerLimit(watts int) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Setting power limit to %d watts from bmc's redfish endpoint", watts)
+
+	if watts < 0 {
+		glog.V(100).Infof("The power limit %d is negative", watts)
+
+		return fmt.Errorf("power limit 'watts' cannot be negative")
+	}
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	power, err := redfishGetPower(redfishClient)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish power: %v", err)
+
+		return fmt.Errorf("failed to get redfish power control: %w", err)
+	}
+
+	if bmc.powerControlIndex >= len(power.PowerControl) {
+		glog.V(100).Infof("Invalid power control index %d", bmc.powerControlIndex)
+
+		return fmt.Errorf("failed to get redfish power control: invalid power control index %d "+
+			"(base-index=0, num power control=%d)", bmc.powerControlIndex, len(power.PowerControl))
+	}
+
+	// gofish does not support updating PowerControl, so the Power resource is patched directly. Empty objects leave
+	// the preceding power controls unchanged.
+	powerControls := make([]map[string]any, bmc.powerControlIndex+1)
+	for index := range powerControls {
+		powerControls[index] = map[string]any{}
+	}
+
+	// A null limit removes the power cap rather than capping the chassis at zero watts.
+	var limitInWatts any = watts
+	if watts == 0 {
+		limitInWatts = nil
+	}
+
+	powerControls[bmc.powerControlIndex]["PowerLimit"] = map[string]any{"LimitInWatts": limitInWatts}
+
+	response, err := redfishClient.Patch(power.ODataID, map[string]any{"PowerControl": powerControls})
+	if err != nil {
+		glog.V(100).Infof("Failed to set power limit: %v", err)
+
+		return fmt.Errorf("failed to set power limit: %w", err)
+	}
+
+	response.Body.Close()
+
+	return nil
+}
+
+// RedfishServiceInfo returns the Redfish version and vendor reported by the service root of the BMC's Redfish API. If
+// the service root does not report a vendor, such as on implementations older than ServiceRoot v1.5.0, the name of the
+// first OEM block is returned instead.
+func (bmc *BMC) RedfishServiceInfo() (version string, vendor string, err error) {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return "", "", err
+	}
+
+	glog.V(100).Info("Getting service root info from bmc's redfish endpoint")
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	service := redfishClient.GetService()
+
+	vendor = service.Vendor
+	if vendor == "" {
+		vendor = redfishGetOEMVendor(service.Oem)
+	}
+
+	return service.RedfishVersion, vendor, nil
+}
+
+// InsertVirtualMedia inserts the provided image into a virtual media slot of the BMC's manager using the Redfish API.
+// The first slot supporting mediaType is used or, if mediaType is empty, the first slot supporting CD or DVD media.
+func (bmc *BMC) InsertVirtualMedia(image string, mediaType redfish.VirtualMediaType) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Inserting virtual media %s from bmc's redfish endpoint", image)
+
+	if image == "" {
+		glog.V(100).Info("The virtual media image is empty")
+
+		return fmt.Errorf("virtual media 'image' cannot be empty")
+	}
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	virtualMedia, err := redfishGetVirtualMedia(redfishClient, mediaType)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish virtual media: %v", err)
+
+		return fmt.Errorf("failed to get redfish virtual media: %w", err)
+	}
+
+	err = virtualMedia.InsertMedia(image, true, true)
+	if err != nil {
+		glog.V(100).Infof("Failed to insert virtual media %s: %v", image, err)
+
+		return fmt.Errorf("failed to insert virtual media %s: %w", image, err)
+	}
+
+	return nil
+}
+
+// InsertVirtualMediaFromShare inserts the image at shareURL, which must be an nfs, cifs, or smb URL, into a virtual
+// media slot of the BMC's manager using the Redfish API. The transfer protocol is derived from the URL scheme and the
+// username and password, if provided, are used to access the share. Slots are selected the same way as in
+// InsertVirtualMedia.
+func (bmc *BMC) InsertVirtualMediaFromShare(
+	shareURL, username, password string, mediaType redfish.VirtualMediaType) error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Inserting virtual media from share %s from bmc's redfish endpoint", shareURL)
+
+	if shareURL == "" {
+		glog.V(100).Info("The virtual media share URL is empty")
+
+		return fmt.Errorf("virtual media 'shareURL' cannot be empty")
+	}
+
+	parsedURL, err := url.Parse(shareURL)
+	if err != nil {
+		glog.V(100).Infof("Failed to parse virtual media share URL %s: %v", shareURL, err)
+
+		return fmt.Errorf("failed to parse virtual media share URL %s: %w", shareURL, err)
+	}
+
+	transferProtocol, found := shareTransferProtocols[strings.ToLower(parsedURL.Scheme)]
+	if !found {
+		glog.V(100).Infof("The virtual media share URL scheme %s is not supported", parsedURL.Scheme)
+
+		return fmt.Errorf("virtual media share URL scheme %q is not one of nfs, cifs, or smb", parsedURL.Scheme)
+	}
+
+	if username != "" && password == "" {
+		glog.V(100).Info("The virtual media share password is empty")
+
+		return fmt.Errorf("virtual media share 'password' cannot be empty when 'username' is set")
+	}
+
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	virtualMedia, err := redfishGetVirtualMedia(redfishClient, mediaType)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish virtual media: %v", err)
+
+		return fmt.Errorf("failed to get redfish virtual media: %w", err)
+	}
+
+	err = virtualMedia.InsertMediaConfig(redfish.VirtualMediaConfig{
+		Image:                shareURL,
+		Inserted:             true,
+		WriteProtected:       true,
+		TransferProtocolType: string(transferProtocol),
+		UserName:             username,
+		Password:             password,
+	})
+	if err != nil {
+		glog.V(100).Infof("Failed to insert virtual media from share %s: %v", shareURL, err)
+
+		return fmt.Errorf("failed to insert virtual media from share %s: %w", shareURL, err)
+	}
+
+	return nil
+}
+
+// EjectVirtualMedia ejects the media from the virtual media slot of the BMC's manager that InsertVirtualMedia uses when
+// mediaType is empty, which is the first slot supporting CD or DVD media, using the Redfish API. Media in other slots,
+// such as USB or floppy images, is left inserted. Nothing is done if the slot has no media inserted.
+func (bmc *BMC) EjectVirtualMedia() error {
+	if valid, err := bmc.validateRedfish(); !valid {
+		return err
+	}
+
+	glog.V(100).Info("Ejecting virtual media from bmc's redfish endpoint")
+
+	return bmc.ejectVirtualMedia("")
+}
+
+// CreateCLISSHSession creates a ssh Session to the host.
+func (bmc *BMC) CreateCLISSHSession() (*ssh.Session, error) {
+	if valid, err := bmc.validateSSH(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Creating SSH session to run commands in the BMC's CLI.")
+
+	config := &ssh.ClientConfig{
+		User:            bmc.sshUser.Name,
+		Auth:            bmc.getSSHAuthMethods(),
+		Timeout:         bmc.timeOuts.SSH,
+		HostKeyCallback: bmc.getSSHHostKeyCallback(),
+	}
+
+	// Establish SSH connection
+	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:%d", bmc.host, bmc.sshPort), config)
+	if err != nil {
+		glog.V(100).Infof("Failed to connect to BMC's SSH server: %v", err)
+
+		return nil, fmt.Errorf("failed to connect to BMC's SSH server: %w", err)
+	}
+
+	// Create a session
+	session, err := client.NewSession()
+	if err != nil {
+		glog.V(100).Infof("Failed to create a new SSH session: %v", err)
+
+		return nil, fmt.Errorf("failed to create a new ssh session: %w", err)
+	}
+
+	return session, nil
+}
+
+// RunCLICommand runs a CLI command in the BMC's console over SSH. This method will block until the command has
+// finished, and its output is copied to stdout and/or stderr if applicable. If combineOutput is true, stderr content is
+// merged in stdout. The timeout param is used to avoid the caller to be stuck forever in case something goes wrong or
+// the command is stuck.
+func (bmc *BMC) RunCLICommand(
+	cmd string, combineOutput bool, timeout time.Duration) (stdout string, stderr string, err error) {
+	stdout, stderr, err = bmc.runCLICommand(cmd, combineOutput, timeout)
+	if err != nil && combineOutput {
+		// The combined output of a failed command is only returned by RunCLICommandWithExitCode, keeping the existing
+		// behavior of RunCLICommand.
+		return "", stderr, err
+	}
+
+	return stdout, stderr, err
+}
+
+// RunCLICommandWithExitCode is like RunCLICommand but also returns the exit code of the command. A command exiting with
+// a non-zero code is not treated as an error, so err is only set if the command could not be run to completion, in
+// which case exitCode is -1.
+func (bmc *BMC) RunCLICommandWithExitCode(
+	cmd string, combineOutput bool, timeout time.Duration) (stdout string, stderr string, exitCode int, err error) {
+	stdout, stderr, err = bmc.runCLICommand(cmd, combineOutput, timeout)
+	if err == nil {
+		return stdout, stderr, 0, nil
+	}
+
+	var exitError *ssh.ExitError
+	if errors.As(err, &exitError) {
+		glog.V(100).Infof("CLI command exited with code %d", exitError.ExitStatus())
+
+		return stdout, stderr, exitError.ExitStatus(), nil
+	}
+
+	return stdout, stderr, -1, err
+}
+
+// OpenSerialConsole opens the serial console port. The console is tunneled in an underlying (CLI) ssh session that is
+// opened in the BMC's ssh server. If openConsoleCliCmd is provided, it will be sent to the BMC's cli. Otherwise, a best
+// effort will be made to run the appropriate cli command based on the system manufacturer. This method requires both a
+// Redfish and SSH user configured.
+func (bmc *BMC) OpenSerialConsole(openConsoleCliCmd string) (io.Reader, io.WriteCloser, error) {
+	// We use both Redfish and SSH so make sure both are valid before continuing.
 	if valid, err := bmc.validateRedfish(); !valid {
-		return 0.0, err
+		return nil, nil, err
 	}
 
-	glog.V(100).Info("Collecting current power usage from bmc's redfish endpoint")
+	if valid, err := bmc.validateSSH(); !valid {
+		return nil, nil, err
+	}
 
-	redfishClient, cancel, err := redfishConnect(
-		bmc.host,
-		bmc.redfishUser.Name,
-		bmc.redfishUser.Password,
-		bmc.timeOuts.Redfish)
-	if err != nil {
-		glog.V(100).Infof("Redfish connection error: %v", err)
+	glog.V(100).Infof("Opening serial console on %v.", bmc.host)
 
-		return 0.0, fmt.Errorf("redfish connection error: %w", err)
+	if bmc.sshSessionForSerialConsole != nil {
+		glog.V(100).Infof("There is already a serial console opened for %v's BMC. Use OpenSerialConsole() first.",
+			bmc.host)
+
+		return nil, nil, fmt.Errorf("there is already a serial console opened for %v's BMC", bmc.host)
 	}
 
-	defer func() {
-		redfishClient.Logout()
-		cancel()
-	}()
+	if openConsoleCliCmd == "" {
+		// no cli command to get console port was provided, try to guess based on
+		// manufacturer.
+		manufacturer, err := bmc.SystemManufacturer()
+		if err != nil {
+			glog.V(100).Infof("Failed to get redifsh system manufacturer for %v: %v", bmc.host, err)
 
-	powerControl, err := redfishGetPowerControl(redfishClient, bmc.powerControlIndex)
+			return nil, nil, fmt.Errorf("failed to get redfish system manufacturer for %v: %w", bmc.host, err)
+		}
+
+		var found bool
+		if openConsoleCliCmd, found = cliCmdSerialConsole[manufacturer]; !found {
+			glog.V(100).Infof("CLI command to get serial console not found for manufacturer for %v: %v",
+				bmc.host, manufacturer)
+
+			return nil, nil, fmt.Errorf("cli command to get serial console not found for manufacturer for %v: %v",
+				bmc.host, manufacturer)
+		}
+	}
+
+	sshSession, err := bmc.CreateCLISSHSession()
 	if err != nil {
-		glog.V(100).Infof("Failed to get redfish power control: %v", err)
+		glog.V(100).Infof("Failed to create underlying ssh session for %v: %v", bmc.host, err)
 
-		return 0.0, fmt.Errorf("failed to get redfish power control: %w", err)
+		return nil, nil, fmt.Errorf("failed to create underlying ssh session for %v: %w", bmc.host, err)
 	}
 
-	return powerControl.PowerConsumedWatts, nil
-}
+	// Pipes need to be retrieved before session.Start()
+	reader, err := sshSession.StdoutPipe()
+	if err != nil {
+		glog.V(100).Infof("Failed to get stdout pipe from %v's ssh session: %v", bmc.host, err)
 
-// CreateCLISSHSession creates a ssh Session to the host.
-func (bmc *BMC) CreateCLISSHSession() (*ssh.Session, error) {
-	if valid, err := bmc.validateSSH(); !valid {
-		return nil, err
+		_ = sshSession.Close()
+
+		return nil, nil, fmt.Errorf("failed to get stdout pipe from %v's ssh session: %w", bmc.host, err)
 	}
 
-	glog.V(100).Infof("Creating SSH session to run commands in the BMC's CLI.")
+	writer, err := sshSession.StdinPipe()
+	if err != nil {
+		glog.V(100).Infof("Failed to get stdin pipe from from %v's ssh session: %w", bmc.host, err)
 
-	config := &ssh.ClientConfig{
-		User: bmc.sshUser.Name,
-		Auth: []ssh.AuthMethod{
-			ssh.Password(bmc.sshUser.Password),
-			ssh.KeyboardInteractive(func(user, instruction string, questions []string,
-				echos []bool) (answers []string, err error) {
-				answers = make([]string, len(questions))
-				// The second parameter is unused
-				for n := range questions {
-					answers[n] = bmc.sshUser.Password
-				}
-
-				return answers, nil
-			}),
-		},
-		Timeout:         bmc.timeOuts.SSH,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		_ = sshSession.Close()
+
+		return nil, nil, fmt.Errorf("failed to get stdin pipe from %v's ssh session: %w", bmc.host, err)
 	}
 
-	// Establish SSH connection
-	client, err := ssh.Dial("tcp", fmt.Sprintf("%s:%d", bmc.host, bmc.sshPort), config)
+	err = sshSession.Start(openConsoleCliCmd)
 	if err != nil {
-		glog.V(100).Infof("Failed to connect to BMC's SSH server: %v", err)
+		glog.V(100).Infof("Failed to start CLI command %q on %v: %v", openConsoleCliCmd, bmc.host, err)
 
-		return nil, fmt.Errorf("failed to connect to BMC's SSH server: %w", err)
+		_ = sshSession.Close()
+
+		return nil, nil, fmt.Errorf(
+			"failed to start serial console with cli command %q on %v: %w", openConsoleCliCmd, bmc.host, err)
 	}
 
-	// Create a session
-	session, err := client.NewSession()
+	go func() { _ = sshSession.Wait() }()
+
+	bmc.sshSessionForSerialConsole = sshSession
+
+	return reader, writer, nil
+}
+
+// CloseSerialConsole closes the serial console's underlying ssh session.
+func (bmc *BMC) CloseSerialConsole() error {
+	if valid, err := bmc.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Closing serial console for %v.", bmc.host)
+
+	if bmc.sshSessionForSerialConsole == nil {
+		glog.V(100).Infof("No underlying ssh session found for %v. Please use OpenSerialConsole() first.", bmc.host)
+
+		return fmt.Errorf("no underlying ssh session found for %v", bmc.host)
+	}
+
+	err := bmc.sshSessionForSerialConsole.Close()
 	if err != nil {
-		glog.V(100).Infof("Failed to create a new SSH session: %v", err)
+		glog.V(100).Infof("Failed to close underlying ssh session for %v: %v", bmc.host, err)
 
-		return nil, fmt.Errorf("failed to create a new ssh session: %w", err)
+		return fmt.Errorf("failed to close underlying ssh session for %v: %w", bmc.host, err)
 	}
 
-	return session, nil
+	bmc.sshSessionForSerialConsole = nil
+
+	return nil
 }
 
-// RunCLICommand runs a CLI command in the BMC's console over SSH. This method will block until the command has
-// finished, and its output is copied to stdout and/or stderr if applicable. If combineOutput is true, stderr content is
-// merged in stdout. The timeout param is used to avoid the caller to be stuck forever in case something goes wrong or
-// the command is stuck.
-func (bmc *BMC) RunCLICommand(
+// runCLICommand implements RunCLICommand. The output is returned even if the command fails so that callers can inspect
+// it along with the error.
+func (bmc *BMC) runCLICommand(
 	cmd string, combineOutput bool, timeout time.Duration) (stdout string, stderr string, err error) {
 	if valid, err := bmc.validateSSH(); !valid {
 		return "", "", err
@@ -718,6 +2337,10 @@ func (bmc *BMC) RunCLICommand(
 		if err != nil {
 			glog.V(100).Infof("Command run error: %v", err)
 
+			if combineOutput {
+				return string(combinedOutput), "", fmt.Errorf("command run error: %w", err)
+			}
+
 			return stdoutBuffer.String(), stderrBuffer.String(), fmt.Errorf("command run error: %w", err)
 		}
 	}
@@ -729,198 +2352,525 @@ func (bmc *BMC) RunCLICommand(
 	return stdoutBuffer.String(), stderrBuffer.String(), nil
 }
 
-// OpenSerialConsole opens the serial console port. The console is tunneled in an underlying (CLI) ssh session that is
-// opened in the BMC's ssh server. If openConsoleCliCmd is provided, it will be sent to the BMC's cli. Otherwise, a best
-// effort will be made to run the appropriate cli command based on the system manufacturer. This method requires both a
-// Redfish and SSH user configured.
-func (bmc *BMC) OpenSerialConsole(openConsoleCliCmd string) (io.Reader, io.WriteCloser, error) {
-	// We use both Redfish and SSH so make sure both are valid before continuing.
-	if valid, err := bmc.validateRedfish(); !valid {
+// getSSHAuthMethods returns the auth methods to use for the SSH user. Public key auth is used when a private key has
+// been provided, otherwise it falls back to password and keyboard-interactive auth.
+func (bmc *BMC) getSSHAuthMethods() []ssh.AuthMethod {
+	if bmc.sshSigner != nil {
+		return []ssh.AuthMethod{ssh.PublicKeys(bmc.sshSigner)}
+	}
+
+	return []ssh.AuthMethod{
+		ssh.Password(bmc.sshUser.Password),
+		ssh.KeyboardInteractive(func(user, instruction string, questions []string,
+			echos []bool) (answers []string, err error) {
+			answers = make([]string, len(questions))
+			// The second parameter is unused
+			for n := range questions {
+				answers[n] = bmc.sshUser.Password
+			}
+
+			return answers, nil
+		}),
+	}
+}
+
+// getSSHHostKeyCallback returns the configured host key callback, defaulting to ignoring the host key if none is set.
+func (bmc *BMC) getSSHHostKeyCallback() ssh.HostKeyCallback {
+	if bmc.sshHostKeyCallback != nil {
+		return bmc.sshHostKeyCallback
+	}
+
+	glog.V(100).Infof("No SSH host key callback set for %s, host key will not be verified", bmc.host)
+
+	return ssh.InsecureIgnoreHostKey()
+}
+
+// redfishConnect uses the provided host, credentials, and timeout to produce a gofish APIClient for accessing the
+// Redfish API. The session context is derived from ctx, so cancelling ctx also cancels the session. A sessionTimeout of
+// zero leaves the session open until it is cancelled. If tlsConfig is nil, the BMC's certificate is not verified.
+// Cancelling the session also closes any idle connections left by the custom transport used for tlsConfig.
+func redfishConnect(
+	ctx context.Context,
+	host, user, password string,
+	sessionTimeout time.Duration,
+	tlsConfig *tls.Config) (*gofish.APIClient, context.CancelFunc, error) {
+	gofishConfig := gofish.ClientConfig{
+		Endpoint: "https://" + host,
+		Username: user,
+		Password: password,
+		Insecure: true,
+	}
+
+	var transport *http.Transport
+
+	if tlsConfig != nil {
+		// Cloning the default transport keeps its dial, handshake, and idle timeouts, which gofish would otherwise set.
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig.Clone()
+
+		gofishConfig.Insecure = false
+		gofishConfig.HTTPClient = &http.Client{Transport: transport}
+	}
+
+	var cancelContext context.CancelFunc
+
+	if sessionTimeout > 0 {
+		ctx, cancelContext = context.WithTimeout(ctx, sessionTimeout)
+	} else {
+		ctx, cancelContext = context.WithCancel(ctx)
+	}
+
+	cancel := func() {
+		cancelContext()
+
+		if transport != nil {
+			transport.CloseIdleConnections()
+		}
+	}
+
+	client, err := gofish.ConnectContext(ctx, gofishConfig)
+	if err != nil {
+		cancel()
+
+		return nil, nil, fmt.Errorf("failed to connect to redfish endpoint: %w", err)
+	}
+
+	return client, cancel, nil
+}
+
+// redfishConnectWithRetries calls redfishConnect with the BMC's host, credentials, and TLS config, retrying with
+// exponential backoff up to the configured number of connect retries. Rejected credentials are returned immediately
+// since retrying cannot fix them. The error of the last attempt is returned if all of them fail.
+func (bmc *BMC) redfishConnectWithRetries(
+	ctx context.Context, sessionTimeout time.Duration) (*gofish.APIClient, context.CancelFunc, error) {
+	var (
+		redfishClient *gofish.APIClient
+		cancel        context.CancelFunc
+	)
+
+	err := retry.Retry(ctx, func() error {
+		var err error
+
+		redfishClient, cancel, err = redfishConnect(
+			ctx,
+			bmc.host,
+			bmc.redfishUser.Name,
+			bmc.redfishUser.Password,
+			sessionTimeout,
+			bmc.redfishTLSConfig)
+
+		return err
+	}, func(err error) bool {
+		var redfishError *common.Error
+
+		return !errors.As(err, &redfishError) || redfishError.HTTPReturnedStatusCode != http.StatusUnauthorized
+	}, bmc.redfishConnectRetries, bmc.redfishRetryInterval)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return redfishClient, cancel, nil
+}
+
+// redfishSession returns the session opened by Connect if there is one, otherwise it opens a new session derived from
+// ctx. The returned release function must be called once the client is no longer needed. For the cached session, it
+// holds the mutex until then so Disconnect cannot log out while the session is in use.
+func (bmc *BMC) redfishSession(ctx context.Context) (*gofish.APIClient, func(), error) {
+	return bmc.redfishSessionWithTimeout(ctx, bmc.timeOuts.Redfish)
+}
+
+// redfishSessionWithTimeout is like redfishSession but a new session is bounded by sessionTimeout rather than the
+// Redfish timeout. It is used by methods that keep the session for longer than a single request. Requests on the
+// cached session are bounded the same way until the release function is called.
+func (bmc *BMC) redfishSessionWithTimeout(
+	ctx context.Context, sessionTimeout time.Duration) (*gofish.APIClient, func(), error) {
+	bmc.redfishMutex.Lock()
+
+	if bmc.redfishClient != nil {
+		var cancel context.CancelFunc
+
+		if sessionTimeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, sessionTimeout)
+		} else {
+			ctx, cancel = context.WithCancel(ctx)
+		}
+
+		// gofish does not allow changing the context of a client, so the requests are given ctx by the transport
+		// instead. This is safe since the mutex is held until the client is released.
+		httpClient := bmc.redfishClient.HTTPClient
+		boundClient := *httpClient
+		boundClient.Transport = &redfishContextTransport{ctx: ctx, base: httpClient.Transport}
+		bmc.redfishClient.HTTPClient = &boundClient
+
+		return bmc.redfishClient, func() {
+			bmc.redfishClient.HTTPClient = httpClient
+
+			cancel()
+			bmc.redfishMutex.Unlock()
+		}, nil
+	}
+
+	bmc.redfishMutex.Unlock()
+
+	redfishClient, cancel, err := bmc.redfishConnectWithRetries(ctx, sessionTimeout)
+	if err != nil {
 		return nil, nil, err
 	}
 
-	if valid, err := bmc.validateSSH(); !valid {
-		return nil, nil, err
+	return redfishClient, func() {
+		redfishClient.Logout()
+		cancel()
+	}, nil
+}
+
+// redfishContextTransport is an http.RoundTripper that sends every request with its context, so that requests on the
+// cached Redfish session can be bounded by the context of each call.
+type redfishContextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+// RoundTrip sends the request using the base transport, or http.DefaultTransport if it is nil, with the context of the
+// redfishContextTransport.
+func (transport *redfishContextTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	base := transport.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return base.RoundTrip(request.WithContext(transport.ctx))
+}
+
+// redfishGetSystem uses the provided gofish APIClient and the system index to get a system from the Redfish API.
+func redfishGetSystem(redfishClient *gofish.APIClient, index int) (*redfish.ComputerSystem, error) {
+	systems, err := redfishClient.GetService().Systems()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get systems: %w", err)
+	}
+
+	if len(systems) < index+1 {
+		return nil, fmt.Errorf("invalid system index %d (base-index=0, num systems=%d)", index, len(systems))
+	}
+
+	return systems[index], nil
+}
+
+// redfishGetSystemSecureBoot uses the provided gofish APIClient and the system index to get the SecureBoot resource for
+// a system.
+func redfishGetSystemSecureBoot(redfishClient *gofish.APIClient, systemIndex int) (*redfish.SecureBoot, error) {
+	system, err := redfishGetSystem(redfishClient, systemIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	sboot, err := system.SecureBoot()
+	if err != nil {
+		return nil, err
+	}
+
+	return sboot, nil
+}
+
+// redfishGetPowerControl gets the specified PowerControl from the first chassis with a power link from the redfish API.
+func redfishGetPowerControl(
+	redfishClient *gofish.APIClient, powerControlIndex int) (*redfish.PowerControl, error) {
+	power, err := redfishGetPower(redfishClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if powerControlIndex >= len(power.PowerControl) {
+		return nil, fmt.Errorf(
+			"invalid power control index %d (base-index=0, num power control=%d)", powerControlIndex, len(power.PowerControl))
+	}
+
+	return &power.PowerControl[powerControlIndex], nil
+}
+
+// redfishGetPower uses the provided gofish APIClient to get the power information of the first chassis with a power
+// link.
+func redfishGetPower(redfishClient *gofish.APIClient) (*redfish.Power, error) {
+	chassisCollection, err := redfishClient.GetService().Chassis()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chassis collection: %w", err)
+	}
+
+	for chassisIndex, chassis := range chassisCollection {
+		power, err := chassis.Power()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get power for chassis index %d: %w", chassisIndex, err)
+		}
+
+		if power == nil {
+			continue
+		}
+
+		return power, nil
 	}
 
-	glog.V(100).Infof("Opening serial console on %v.", bmc.host)
-
-	if bmc.sshSessionForSerialConsole != nil {
-		glog.V(100).Infof("There is already a serial console opened for %v's BMC. Use OpenSerialConsole() first.",
-			bmc.host)
+	return nil, fmt.Errorf("failed to get power control: no chassis with power link found")
+}
 
-		return nil, nil, fmt.Errorf("there is already a serial console opened for %v's BMC", bmc.host)
+// redfishGetAccountService uses the provided gofish APIClient to get the AccountService of the Redfish API. Since gofish
+// does not expose the link and fetches an empty URI when it is missing, the service root is fetched again and the link
+// decoded directly so that an error is returned if the AccountService is absent.
+func redfishGetAccountService(redfishClient *gofish.APIClient) (*redfish.AccountService, error) {
+	response, err := redfishClient.Get(common.DefaultServiceRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service root: %w", err)
 	}
 
-	if openConsoleCliCmd == "" {
-		// no cli command to get console port was provided, try to guess based on
-		// manufacturer.
-		manufacturer, err := bmc.SystemManufacturer()
-		if err != nil {
-			glog.V(100).Infof("Failed to get redifsh system manufacturer for %v: %v", bmc.host, err)
+	defer response.Body.Close()
 
-			return nil, nil, fmt.Errorf("failed to get redfish system manufacturer for %v: %w", bmc.host, err)
-		}
+	var serviceRoot struct {
+		AccountService common.Link
+	}
 
-		var found bool
-		if openConsoleCliCmd, found = cliCmdSerialConsole[manufacturer]; !found {
-			glog.V(100).Infof("CLI command to get serial console not found for manufacturer for %v: %v",
-				bmc.host, manufacturer)
+	err = json.NewDecoder(response.Body).Decode(&serviceRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode service root: %w", err)
+	}
 
-			return nil, nil, fmt.Errorf("cli command to get serial console not found for manufacturer for %v: %v",
-				bmc.host, manufacturer)
-		}
+	if serviceRoot.AccountService.String() == "" {
+		return nil, fmt.Errorf("service root does not link to an account service")
 	}
 
-	sshSession, err := bmc.CreateCLISSHSession()
+	return redfishClient.GetService().AccountService()
+}
+
+// redfishGetAccount uses the provided gofish APIClient to get the account matching username from the AccountService
+// of the Redfish API. An error is returned if the AccountService is disabled, making its accounts read-only.
+func redfishGetAccount(redfishClient *gofish.APIClient, username string) (*redfish.ManagerAccount, error) {
+	accountService, err := redfishGetAccountService(redfishClient)
 	if err != nil {
-		glog.V(100).Infof("Failed to create underlying ssh session for %v: %v", bmc.host, err)
+		return nil, fmt.Errorf("failed to get account service: %w", err)
+	}
 
-		return nil, nil, fmt.Errorf("failed to create underlying ssh session for %v: %w", bmc.host, err)
+	if !accountService.ServiceEnabled {
+		return nil, fmt.Errorf("account service is disabled and its accounts are read-only")
 	}
 
-	// Pipes need to be retrieved before session.Start()
-	reader, err := sshSession.StdoutPipe()
+	accounts, err := accountService.Accounts()
 	if err != nil {
-		glog.V(100).Infof("Failed to get stdout pipe from %v's ssh session: %v", bmc.host, err)
-
-		_ = sshSession.Close()
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
 
-		return nil, nil, fmt.Errorf("failed to get stdout pipe from %v's ssh session: %w", bmc.host, err)
+	for _, account := range accounts {
+		if account.UserName == username {
+			return account, nil
+		}
 	}
 
-	writer, err := sshSession.StdinPipe()
-	if err != nil {
-		glog.V(100).Infof("Failed to get stdin pipe from from %v's ssh session: %w", bmc.host, err)
+	return nil, fmt.Errorf("account %s not found", username)
+}
 
-		_ = sshSession.Close()
+// redfishGetIndicatorLEDChassis uses the provided gofish APIClient to get the first chassis exposing an indicator LED.
+func redfishGetIndicatorLEDChassis(redfishClient *gofish.APIClient) (*redfish.Chassis, error) {
+	chassisCollection, err := redfishClient.GetService().Chassis()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chassis collection: %w", err)
+	}
 
-		return nil, nil, fmt.Errorf("failed to get stdin pipe from %v's ssh session: %w", bmc.host, err)
+	for _, chassis := range chassisCollection {
+		if chassis.IndicatorLED != "" {
+			return chassis, nil
+		}
 	}
 
-	err = sshSession.Start(openConsoleCliCmd)
+	return nil, fmt.Errorf("failed to get indicator LED: no chassis with indicator LED found")
+}
+
+// redfishGetIntrusionSensorChassis uses the provided gofish APIClient to get the first chassis exposing an intrusion
+// sensor as part of its physical security.
+func redfishGetIntrusionSensorChassis(redfishClient *gofish.APIClient) (*redfish.Chassis, error) {
+	chassisCollection, err := redfishClient.GetService().Chassis()
 	if err != nil {
-		glog.V(100).Infof("Failed to start CLI command %q on %v: %v", openConsoleCliCmd, bmc.host, err)
+		return nil, fmt.Errorf("failed to get chassis collection: %w", err)
+	}
 
-		_ = sshSession.Close()
+	for _, chassis := range chassisCollection {
+		if chassis.PhysicalSecurity.IntrusionSensor != "" {
+			return chassis, nil
+		}
+	}
 
-		return nil, nil, fmt.Errorf(
-			"failed to start serial console with cli command %q on %v: %w", openConsoleCliCmd, bmc.host, err)
+	return nil, fmt.Errorf("failed to get intrusion sensor: no chassis with physical security found")
+}
+
+// redfishGetThermal uses the provided gofish APIClient to get the thermal information of the first chassis with a
+// thermal link.
+func redfishGetThermal(redfishClient *gofish.APIClient) (*redfish.Thermal, error) {
+	chassisCollection, err := redfishClient.GetService().Chassis()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chassis collection: %w", err)
 	}
 
-	go func() { _ = sshSession.Wait() }()
+	for chassisIndex, chassis := range chassisCollection {
+		thermal, err := chassis.Thermal()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get thermal for chassis index %d: %w", chassisIndex, err)
+		}
 
-	bmc.sshSessionForSerialConsole = sshSession
+		if thermal == nil {
+			continue
+		}
 
-	return reader, writer, nil
+		return thermal, nil
+	}
+
+	return nil, fmt.Errorf("failed to get thermal: no chassis with thermal link found")
 }
 
-// CloseSerialConsole closes the serial console's underlying ssh session.
-func (bmc *BMC) CloseSerialConsole() error {
-	if valid, err := bmc.validate(); !valid {
-		return err
+// redfishGetBootProgress uses the provided gofish APIClient to get the BootProgress LastState of the system. Since
+// gofish does not expose BootProgress, the system is fetched again and the property decoded directly. An empty value is
+// returned if the system does not report BootProgress.
+func redfishGetBootProgress(
+	redfishClient *gofish.APIClient, system *redfish.ComputerSystem) (BootProgressTypes, error) {
+	response, err := redfishClient.Get(system.ODataID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get system %s: %w", system.ODataID, err)
 	}
 
-	glog.V(100).Infof("Closing serial console for %v.", bmc.host)
-
-	if bmc.sshSessionForSerialConsole == nil {
-		glog.V(100).Infof("No underlying ssh session found for %v. Please use OpenSerialConsole() first.", bmc.host)
+	defer response.Body.Close()
 
-		return fmt.Errorf("no underlying ssh session found for %v", bmc.host)
+	var systemBootProgress struct {
+		BootProgress struct {
+			LastState BootProgressTypes
+		}
 	}
 
-	err := bmc.sshSessionForSerialConsole.Close()
+	err = json.NewDecoder(response.Body).Decode(&systemBootProgress)
 	if err != nil {
-		glog.V(100).Infof("Failed to close underlying ssh session for %v: %v", bmc.host, err)
+		return "", fmt.Errorf("failed to decode system %s: %w", system.ODataID, err)
+	}
 
-		return fmt.Errorf("failed to close underlying ssh session for %v: %w", bmc.host, err)
+	return systemBootProgress.BootProgress.LastState, nil
+}
+
+// redfishGetManager uses the provided gofish APIClient to get the first manager from the Redfish API.
+func redfishGetManager(redfishClient *gofish.APIClient) (*redfish.Manager, error) {
+	managers, err := redfishClient.GetService().Managers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get managers: %w", err)
 	}
 
-	bmc.sshSessionForSerialConsole = nil
+	if len(managers) == 0 {
+		return nil, fmt.Errorf("no managers found")
+	}
 
-	return nil
+	return managers[0], nil
 }
 
-// redfishConnect uses the provided host, credentials, and timeout to produce a gofish APIClient for accessing the
-// Redfish API.
-func redfishConnect(
-	host, user, password string, sessionTimeout time.Duration) (*gofish.APIClient, context.CancelFunc, error) {
-	gofishConfig := gofish.ClientConfig{
-		Endpoint: "https://" + host,
-		Username: user,
-		Password: password,
-		Insecure: true,
+// redfishGetSystemManager uses the provided gofish APIClient to get the first manager responsible for the system.
+func redfishGetSystemManager(
+	redfishClient *gofish.APIClient, system *redfish.ComputerSystem) (*redfish.Manager, error) {
+	if len(system.ManagedBy) == 0 {
+		return nil, fmt.Errorf("system is not managed by any manager")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), sessionTimeout)
-
-	client, err := gofish.ConnectContext(ctx, gofishConfig)
+	manager, err := redfish.GetManager(redfishClient, system.ManagedBy[0])
 	if err != nil {
-		cancel()
-
-		return nil, nil, fmt.Errorf("failed to connect to redfish endpoint: %w", err)
+		return nil, fmt.Errorf("failed to get manager %s: %w", system.ManagedBy[0], err)
 	}
 
-	return client, cancel, nil
+	return manager, nil
 }
 
-// redfishGetSystem uses the provided gofish APIClient and the system index to get a system from the Redfish API.
-func redfishGetSystem(redfishClient *gofish.APIClient, index int) (*redfish.ComputerSystem, error) {
-	systems, err := redfishClient.GetService().Systems()
+// redfishGetSELLogService uses the provided gofish APIClient to get the System Event Log (SEL) log service from the
+// log services of the first manager or, if not found there, from those of the system at systemIndex. The system is only
+// fetched if the manager does not have the SEL log service.
+func redfishGetSELLogService(redfishClient *gofish.APIClient, systemIndex int) (*redfish.LogService, error) {
+	manager, err := redfishGetManager(redfishClient)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get systems: %w", err)
+		return nil, fmt.Errorf("failed to get redfish manager: %w", err)
 	}
 
-	if len(systems) < index+1 {
-		return nil, fmt.Errorf("invalid system index %d (base-index=0, num systems=%d)", index, len(systems))
+	logServices, err := manager.LogServices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manager log services: %w", err)
 	}
 
-	return systems[index], nil
-}
+	if logService := redfishFindSELLogService(logServices); logService != nil {
+		return logService, nil
+	}
 
-// redfishGetSystemSecureBoot uses the provided gofish APIClient and the system index to get the SecureBoot resource for
-// a system.
-func redfishGetSystemSecureBoot(redfishClient *gofish.APIClient, systemIndex int) (*redfish.SecureBoot, error) {
 	system, err := redfishGetSystem(redfishClient, systemIndex)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get redfish system: %w", err)
 	}
 
-	sboot, err := system.SecureBoot()
+	systemLogServices, err := system.LogServices()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to get system log services: %w", err)
 	}
 
-	return sboot, nil
+	if logService := redfishFindSELLogService(systemLogServices); logService != nil {
+		return logService, nil
+	}
+
+	return nil, fmt.Errorf("failed to get SEL log service: no SEL log service found")
 }
 
-// redfishGetPowerControl gets the specified PowerControl from the first chassis with a power link from the redfish API.
-func redfishGetPowerControl(
-	redfishClient *gofish.APIClient, powerControlIndex int) (*redfish.PowerControl, error) {
-	chassisCollection, err := redfishClient.GetService().Chassis()
+// redfishFindSELLogService returns the first of the provided log services that is the SEL log service, or nil if there
+// is none.
+func redfishFindSELLogService(logServices []*redfish.LogService) *redfish.LogService {
+	for _, logService := range logServices {
+		if logService.LogEntryType == redfish.SELLogEntryTypes || strings.EqualFold(logService.ID, "sel") {
+			return logService
+		}
+	}
+
+	return nil
+}
+
+// redfishGetVirtualMedia gets the first virtual media slot of the first manager supporting mediaType from the Redfish
+// API. If mediaType is empty, the first slot supporting either CD or DVD media is returned.
+func redfishGetVirtualMedia(
+	redfishClient *gofish.APIClient, mediaType redfish.VirtualMediaType) (*redfish.VirtualMedia, error) {
+	manager, err := redfishGetManager(redfishClient)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get chassis collection: %w", err)
+		return nil, fmt.Errorf("failed to get redfish manager: %w", err)
 	}
 
-	for chassisIndex, chassis := range chassisCollection {
-		power, err := chassis.Power()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get power for chassis index %d: %w", chassisIndex, err)
-		}
+	virtualMediaCollection, err := manager.VirtualMedia()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get virtual media collection: %w", err)
+	}
 
-		if power == nil {
-			continue
-		}
+	acceptedTypes := []redfish.VirtualMediaType{mediaType}
+	if mediaType == "" {
+		acceptedTypes = []redfish.VirtualMediaType{redfish.CDMediaType, redfish.DVDMediaType}
+	}
 
-		if powerControlIndex >= len(power.PowerControl) {
-			return nil, fmt.Errorf(
-				"invalid power control index %d (base-index=0, num power control=%d)", powerControlIndex, len(power.PowerControl))
+	for _, virtualMedia := range virtualMediaCollection {
+		for _, supportedType := range virtualMedia.MediaTypes {
+			if slices.Contains(acceptedTypes, supportedType) {
+				return virtualMedia, nil
+			}
 		}
+	}
+
+	return nil, fmt.Errorf("failed to get virtual media: no slot supporting media types %v found", acceptedTypes)
+}
 
-		return &power.PowerControl[powerControlIndex], nil
+// redfishGetOEMVendor returns the name of the first vendor in the provided OEM block, or an empty string if there are
+// none. Keys are sorted so the result is deterministic when there are multiple vendors.
+func redfishGetOEMVendor(oem json.RawMessage) string {
+	if len(oem) == 0 {
+		return ""
 	}
 
-	return nil, fmt.Errorf("failed to get power control: no chassis with power link found")
+	var vendors map[string]json.RawMessage
+
+	err := json.Unmarshal(oem, &vendors)
+	if err != nil || len(vendors) == 0 {
+		return ""
+	}
+
+	vendorNames := maps.Keys(vendors)
+	slices.Sort(vendorNames)
+
+	return vendorNames[0]
 }
 
 // validateRedfish performs the same validations as in validate but also checks for a valid redfish user.
@@ -979,22 +2929,185 @@ func isResetTypeSupported(resetType redfish.ResetType, supportedTypes []redfish.
 	return false
 }
 
+// getPowerStateAndBootProgress returns the system's power state and BootProgress LastState using a single Redfish
+// session. The boot progress is empty if the system does not report it.
+func (bmc *BMC) getPowerStateAndBootProgress(ctx context.Context) (redfish.PowerState, BootProgressTypes, error) {
+	redfishClient, release, err := bmc.redfishSession(ctx)
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return "", "", fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system: %v", err)
+
+		return "", "", fmt.Errorf("failed to get redfish system: %w", err)
+	}
+
+	bootProgress, err := redfishGetBootProgress(redfishClient, system)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish system's boot progress: %v", err)
+
+		return "", "", fmt.Errorf("failed to get boot progress: %w", err)
+	}
+
+	return system.PowerState, bootProgress, nil
+}
+
+// bootFromInsertedVirtualMedia sets a one-time CD boot source override and resets the system, waiting up to timeout
+// for it to be powered on if timeout is greater than zero. It is separate from BootFromVirtualMedia so that any failure
+// can be handled by ejecting the media in one place.
+func (bmc *BMC) bootFromInsertedVirtualMedia(timeout time.Duration) error {
+	err := bmc.SetBootSourceOverride(redfish.CdBootSourceOverrideTarget, redfish.OnceBootSourceOverrideEnabled)
+	if err != nil {
+		return fmt.Errorf("failed to set boot source override: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var initialBootProgress BootProgressTypes
+
+	if timeout > 0 {
+		_, initialBootProgress, err = bmc.getPowerStateAndBootProgress(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get system's initial boot progress: %w", err)
+		}
+	}
+
+	err = bmc.SystemForceReset()
+	if err != nil {
+		return fmt.Errorf("failed to perform ForceRestart system reset: %w", err)
+	}
+
+	if timeout <= 0 {
+		return nil
+	}
+
+	if initialBootProgress == "" {
+		glog.V(100).Infof("System does not report BootProgress, not waiting for it to restart")
+
+		return nil
+	}
+
+	return bmc.waitForRestart(ctx, initialBootProgress)
+}
+
+// waitForPowerState polls the system's power state until done returns true for it or ctx is done. Errors getting the
+// power state are retried, so only the context error is returned.
+func (bmc *BMC) waitForPowerState(ctx context.Context, done func(redfish.PowerState) bool) error {
+	return wait.PollUntilContextCancel(ctx, powerOnPollInterval, true, func(ctx context.Context) (bool, error) {
+		powerState, err := bmc.GetPowerStateContext(ctx)
+		if err != nil {
+			glog.V(100).Infof("Failed to get system's power state: %v", err)
+
+			return false, nil
+		}
+
+		glog.V(100).Infof("System's current power state: %v", powerState)
+
+		return done(powerState), nil
+	})
+}
+
+// waitForRestart waits for the system to go down and come back up after a reset, using its BootProgress LastState
+// since the power state may stay On throughout. The system is down once the LastState changes from initialBootProgress,
+// the state before the reset, and up once it is On with a LastState of initialBootProgress or OSRunning again.
+func (bmc *BMC) waitForRestart(ctx context.Context, initialBootProgress BootProgressTypes) error {
+	err := bmc.waitForSystemState(ctx, func(_ redfish.PowerState, bootProgress BootProgressTypes) bool {
+		return bootProgress != initialBootProgress
+	})
+	if err != nil {
+		glog.V(100).Infof("Failure waiting for system to go down: %v", err)
+
+		return fmt.Errorf("failure waiting for system to go down: %w", err)
+	}
+
+	err = bmc.waitForSystemState(ctx, func(powerState redfish.PowerState, bootProgress BootProgressTypes) bool {
+		if bootProgress != initialBootProgress && bootProgress != OSRunningBootProgressTypes {
+			return false
+		}
+
+		return powerState == redfish.OnPowerState
+	})
+	if err != nil {
+		glog.V(100).Infof("Failure waiting for system to come back up: %v", err)
+
+		return fmt.Errorf("failure waiting for system to come back up: %w", err)
+	}
+
+	return nil
+}
+
+// waitForSystemState polls the system's power state and boot progress until done returns true for them or ctx is done.
+// The boot progress passed to done is empty if the system does not report it. Errors getting either are retried, so
+// only the context error is returned.
+func (bmc *BMC) waitForSystemState(
+	ctx context.Context, done func(redfish.PowerState, BootProgressTypes) bool) error {
+	return wait.PollUntilContextCancel(ctx, powerOnPollInterval, true, func(ctx context.Context) (bool, error) {
+		powerState, bootProgress, err := bmc.getPowerStateAndBootProgress(ctx)
+		if err != nil {
+			glog.V(100).Infof("Failed to get system's power state and boot progress: %v", err)
+
+			return false, nil
+		}
+
+		glog.V(100).Infof("System's current power state: %v, boot progress: %s", powerState, bootProgress)
+
+		return done(powerState, bootProgress), nil
+	})
+}
+
+// ejectVirtualMedia ejects the media from the virtual media slot that InsertVirtualMedia would use for mediaType, if
+// the slot has media inserted.
+func (bmc *BMC) ejectVirtualMedia(mediaType redfish.VirtualMediaType) error {
+	redfishClient, release, err := bmc.redfishSession(context.Background())
+	if err != nil {
+		glog.V(100).Infof("Redfish connection error: %v", err)
+
+		return fmt.Errorf("redfish connection error: %w", err)
+	}
+
+	defer release()
+
+	virtualMedia, err := redfishGetVirtualMedia(redfishClient, mediaType)
+	if err != nil {
+		glog.V(100).Infof("Failed to get redfish virtual media: %v", err)
+
+		return fmt.Errorf("failed to get redfish virtual media: %w", err)
+	}
+
+	if !virtualMedia.Inserted {
+		glog.V(100).Infof("Virtual media slot %s has no media inserted", virtualMedia.ID)
+
+		return nil
+	}
+
+	glog.V(100).Infof("Ejecting virtual media %s from slot %s", virtualMedia.Image, virtualMedia.ID)
+
+	err = virtualMedia.EjectMedia()
+	if err != nil {
+		glog.V(100).Infof("Failed to eject virtual media from slot %s: %v", virtualMedia.ID, err)
+
+		return fmt.Errorf("failed to eject virtual media from slot %s: %w", virtualMedia.ID, err)
+	}
+
+	return nil
+}
+
 func (bmc *BMC) getSupportedResetTypes() ([]redfish.ResetType, error) {
-	redfishClient, cancel, err := redfishConnect(
-		bmc.host,
-		bmc.redfishUser.Name,
-		bmc.redfishUser.Password,
-		bmc.timeOuts.Redfish)
+	redfishClient, release, err := bmc.redfishSession(context.Background())
 	if err != nil {
 		glog.V(100).Infof("Redfish connection error: %v", err)
 
 		return nil, fmt.Errorf("redfish connection error: %w", err)
 	}
 
-	defer func() {
-		redfishClient.Logout()
-		cancel()
-	}()
+	defer release()
 
 	system, err := redfishGetSystem(redfishClient, bmc.systemIndex)
 	if err != nil {
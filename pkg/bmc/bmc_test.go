@@ -1,20 +1,32 @@
 package bmc
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
 	_ "embed"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"net"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stmcginnis/gofish/common"
 	"github.com/stmcginnis/gofish/redfish"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/utils/ptr"
 )
 
 //go:embed testdata/redfish_v1.json
@@ -46,6 +58,84 @@ var redfishChassisNoPowerJSONResponse string
 //go:embed testdata/redfish_v1_power.json
 var redfishPowerJSONResponse string
 
+//go:embed testdata/redfish_v1_thermal.json
+var redfishThermalJSONResponse string
+
+//go:embed testdata/redfish_v1_accountservice.json
+var redfishAccountServiceJSONResponse string
+
+//go:embed testdata/redfish_v1_accounts.json
+var redfishAccountsJSONResponse string
+
+//go:embed testdata/redfish_v1_account_2.json
+var redfishAccount2JSONResponse string
+
+//go:embed testdata/redfish_v1_account_3.json
+var redfishAccount3JSONResponse string
+
+//go:embed testdata/redfish_v1_updateservice.json
+var redfishUpdateServiceJSONResponse string
+
+//go:embed testdata/redfish_v1_firmwareinventory.json
+var redfishFirmwareInventoryJSONResponse string
+
+//go:embed testdata/redfish_v1_firmwareinventory_bios.json
+var redfishFirmwareInventoryBIOSJSONResponse string
+
+//go:embed testdata/redfish_v1_firmwareinventory_idrac.json
+var redfishFirmwareInventoryIDRACJSONResponse string
+
+//go:embed testdata/redfish_v1_managers.json
+var redfishManagersJSONResponse string
+
+//go:embed testdata/redfish_v1_manager.json
+var redfishManagerJSONResponse string
+
+//go:embed testdata/redfish_v1_manager_logservices.json
+var redfishLogServicesJSONResponse string
+
+//go:embed testdata/redfish_v1_manager_logservice_lclog.json
+var redfishLogServiceLclogJSONResponse string
+
+//go:embed testdata/redfish_v1_manager_logservice_sel.json
+var redfishLogServiceSELJSONResponse string
+
+//go:embed testdata/redfish_v1_manager_logservice_sel_entries.json
+var redfishSELEntriesJSONResponse string
+
+//go:embed testdata/redfish_v1_manager_logservice_sel_entry_1.json
+var redfishSELEntry1JSONResponse string
+
+//go:embed testdata/redfish_v1_manager_logservice_sel_entry_2.json
+var redfishSELEntry2JSONResponse string
+
+//go:embed testdata/redfish_v1_manager_virtualmediacollection.json
+var redfishVirtualMediaCollectionJSONResponse string
+
+//go:embed testdata/redfish_v1_manager_virtualmedia_cd.json
+var redfishVirtualMediaCDJSONResponse string
+
+//go:embed testdata/redfish_v1_manager_virtualmedia_removabledisk.json
+var redfishVirtualMediaRemovableDiskJSONResponse string
+
+//go:embed testdata/redfish_v1_storagecollection.json
+var redfishStorageCollectionJSONResponse string
+
+//go:embed testdata/redfish_v1_storage_raid.json
+var redfishStorageRAIDJSONResponse string
+
+//go:embed testdata/redfish_v1_storage_raid_drive_0.json
+var redfishStorageRAIDDrive0JSONResponse string
+
+//go:embed testdata/redfish_v1_storage_raid_drive_1.json
+var redfishStorageRAIDDrive1JSONResponse string
+
+//go:embed testdata/redfish_v1_storage_ahci.json
+var redfishStorageAHCIJSONResponse string
+
+//go:embed testdata/redfish_v1_storage_ahci_drive_0.json
+var redfishStorageAHCIDrive0JSONResponse string
+
 // redfishAuth is used to unmarshall the received login request redfish credentials.
 type redfishAuth struct {
 	UserName string
@@ -53,12 +143,19 @@ type redfishAuth struct {
 }
 
 type redfishAPIResponseCallbacks struct {
-	v1         func(r *http.Request)
-	sessions   func(r *http.Request)
-	system     func(r *http.Request)
-	secureBoot func(r *http.Request)
-	chassis    func(r *http.Request)
-	power      func(r *http.Request)
+	v1           func(r *http.Request)
+	sessions     func(r *http.Request)
+	system       func(r *http.Request)
+	secureBoot   func(r *http.Request)
+	chassis      func(r *http.Request)
+	power        func(r *http.Request)
+	virtualMedia func(r *http.Request)
+	account      func(r *http.Request)
+	chassisLED   func(r *http.Request)
+	clearLog     func(r *http.Request)
+
+	// overrides maps request paths to handlers that replace the default responses of the fake redfish server.
+	overrides map[string]http.HandlerFunc
 }
 
 const (
@@ -185,6 +282,53 @@ func TestBMCWithRedfishTimeout(t *testing.T) {
 	}
 }
 
+func TestBMCWithRedfishConnectRetries(t *testing.T) {
+	testCases := []struct {
+		name           string
+		retries        int
+		interval       time.Duration
+		expectedErrMsg string
+	}{
+		{
+			name:           "everything alright",
+			retries:        3,
+			interval:       time.Second,
+			expectedErrMsg: "",
+		},
+		{
+			name:           "zero retries",
+			retries:        0,
+			interval:       time.Second,
+			expectedErrMsg: "",
+		},
+		{
+			name:           "negative retries",
+			retries:        -1,
+			interval:       time.Second,
+			expectedErrMsg: "redfish connect 'retries' cannot be negative",
+		},
+		{
+			name:           "zero interval",
+			retries:        3,
+			interval:       0,
+			expectedErrMsg: "redfish retry 'interval' cannot be less than or equal to zero",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bmc := New(defaultHost).WithRedfishConnectRetries(testCase.retries, testCase.interval)
+
+			assert.Equal(t, testCase.expectedErrMsg, bmc.errorMsg)
+
+			if testCase.expectedErrMsg == "" {
+				assert.Equal(t, testCase.retries, bmc.redfishConnectRetries)
+				assert.Equal(t, testCase.interval, bmc.redfishRetryInterval)
+			}
+		})
+	}
+}
+
 func TestBMCWithRedfishSystemIndex(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -293,6 +437,142 @@ func TestBMCWithSSHUser(t *testing.T) {
 		})
 	}
 }
+func TestBMCWithSSHPrivateKey(t *testing.T) {
+	privateKey := generateSSHPrivateKey(t, "")
+	encryptedPrivateKey := generateSSHPrivateKey(t, defaultPassword)
+
+	testCases := []struct {
+		name           string
+		username       string
+		privateKey     []byte
+		passphrase     string
+		expectedErrMsg string
+	}{
+		{
+			name:           "everything alright",
+			username:       defaultUsername,
+			privateKey:     privateKey,
+			passphrase:     "",
+			expectedErrMsg: "",
+		},
+		{
+			name:           "encrypted key with passphrase",
+			username:       defaultUsername,
+			privateKey:     encryptedPrivateKey,
+			passphrase:     defaultPassword,
+			expectedErrMsg: "",
+		},
+		{
+			name:           "username empty",
+			username:       "",
+			privateKey:     privateKey,
+			passphrase:     "",
+			expectedErrMsg: "ssh 'username' cannot be empty",
+		},
+		{
+			name:           "private key empty",
+			username:       defaultUsername,
+			privateKey:     nil,
+			passphrase:     "",
+			expectedErrMsg: "ssh 'privateKey' cannot be empty",
+		},
+		{
+			name:           "private key invalid",
+			username:       defaultUsername,
+			privateKey:     []byte("not a key"),
+			passphrase:     "",
+			expectedErrMsg: "failed to parse ssh 'privateKey': ssh: no key found",
+		},
+		{
+			name:           "encrypted key with wrong passphrase",
+			username:       defaultUsername,
+			privateKey:     encryptedPrivateKey,
+			passphrase:     "wrong",
+			expectedErrMsg: "failed to parse ssh 'privateKey': x509: decryption password incorrect",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bmc := New(defaultHost).
+				WithSSHUser(defaultUsername, defaultPassword).
+				WithSSHPrivateKey(testCase.username, testCase.privateKey, testCase.passphrase)
+
+			assert.Equal(t, testCase.expectedErrMsg, bmc.errorMsg)
+
+			if testCase.expectedErrMsg == "" {
+				assert.Equal(t, testCase.username, bmc.sshUser.Name)
+				assert.Empty(t, bmc.sshUser.Password)
+				assert.NotNil(t, bmc.sshSigner)
+				assert.Len(t, bmc.getSSHAuthMethods(), 1)
+
+				// Setting a password user afterwards falls back to password authentication.
+				bmc = bmc.WithSSHUser(defaultUsername, defaultPassword)
+				assert.Nil(t, bmc.sshSigner)
+				assert.Len(t, bmc.getSSHAuthMethods(), 2)
+			}
+		})
+	}
+}
+
+func TestBMCWithRedfishTLSConfig(t *testing.T) {
+	bmc := New(defaultHost).WithRedfishTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12})
+	assert.Empty(t, bmc.errorMsg)
+	assert.NotNil(t, bmc.redfishTLSConfig)
+
+	bmc = New(defaultHost).WithRedfishTLSConfig(nil)
+	assert.Equal(t, "redfish 'tlsConfig' cannot be nil", bmc.errorMsg)
+}
+
+func TestBMCRedfishTLSVerification(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+
+	trustedCAs := x509.NewCertPool()
+	trustedCAs.AddCert(redfishServer.Certificate())
+
+	testCases := []struct {
+		name          string
+		tlsConfig     *tls.Config
+		expectedError string
+	}{
+		{
+			name:      "nil config skips verification",
+			tlsConfig: nil,
+		},
+		{
+			name:      "config trusting the server certificate",
+			tlsConfig: &tls.Config{RootCAs: trustedCAs, MinVersion: tls.VersionTLS12},
+		},
+		{
+			name:          "config not trusting the server certificate",
+			tlsConfig:     &tls.Config{RootCAs: x509.NewCertPool(), MinVersion: tls.VersionTLS12},
+			expectedError: "certificate signed by unknown authority",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+			if testCase.tlsConfig != nil {
+				bmc = bmc.WithRedfishTLSConfig(testCase.tlsConfig)
+			}
+
+			_, err := bmc.SystemManufacturer()
+
+			if testCase.expectedError != "" {
+				assert.ErrorContains(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
 func TestBMCWithSSHPort(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -360,6 +640,267 @@ func TestBMCWithSSHTimeout(t *testing.T) {
 	}
 }
 
+func TestBMCVerifyRedfishCredentials(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+
+	err := New(host).WithRedfishUser(defaultUsername, defaultPassword).VerifyRedfishCredentials()
+	assert.NoError(t, err)
+
+	// Now reject the login request, simulating a wrong password.
+	unauthorizedServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/SessionService/Sessions": func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			},
+		},
+	})
+	defer unauthorizedServer.Close()
+
+	host = strings.Split(unauthorizedServer.URL, "//")[1]
+
+	err = New(host).WithRedfishUser(defaultUsername, defaultPassword).VerifyRedfishCredentials()
+	assert.EqualError(t, err, fmt.Sprintf("invalid redfish credentials for user %s", defaultUsername))
+
+	// Finally, close the server so the failure is a connection error rather than an authentication one.
+	unauthorizedServer.Close()
+
+	err = New(host).WithRedfishUser(defaultUsername, defaultPassword).VerifyRedfishCredentials()
+	assert.ErrorContains(t, err, "redfish connection error: ")
+	assert.NotContains(t, err.Error(), "invalid redfish credentials")
+}
+
+//nolint:funlen
+func TestBMCChangeAccountPassword(t *testing.T) {
+	const newPassword = "newpass"
+
+	testCases := []struct {
+		username       string
+		newPassword    string
+		overrides      map[string]http.HandlerFunc
+		expectedPath   string
+		expectedError  string
+		updatesBMCUser bool
+	}{
+		{
+			username:       defaultUsername,
+			newPassword:    newPassword,
+			expectedPath:   "/redfish/v1/AccountService/Accounts/2",
+			updatesBMCUser: true,
+		},
+		{
+			username:     "operator",
+			newPassword:  newPassword,
+			expectedPath: "/redfish/v1/AccountService/Accounts/3",
+		},
+		{
+			username:      "nonexistent",
+			newPassword:   newPassword,
+			expectedError: "failed to get redfish account: account nonexistent not found",
+		},
+		{
+			username:    defaultUsername,
+			newPassword: newPassword,
+			overrides: map[string]http.HandlerFunc{
+				"/redfish/v1/AccountService": func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(strings.Replace(
+						redfishAccountServiceJSONResponse, `"ServiceEnabled": true`, `"ServiceEnabled": false`, 1)))
+				},
+			},
+			expectedError: "failed to get redfish account: account service is disabled and its accounts are read-only",
+		},
+		{
+			username:    defaultUsername,
+			newPassword: newPassword,
+			overrides: map[string]http.HandlerFunc{
+				"/redfish/v1/AccountService/Accounts/2": func(w http.ResponseWriter, r *http.Request) {
+					if r.Method == http.MethodPatch {
+						w.WriteHeader(http.StatusMethodNotAllowed)
+
+						return
+					}
+
+					_, _ = w.Write([]byte(redfishAccount2JSONResponse))
+				},
+			},
+			expectedError: "redfish account user1 is read-only",
+		},
+		{
+			username:      "",
+			newPassword:   newPassword,
+			expectedError: "account 'username' cannot be empty",
+		},
+		{
+			username:      defaultUsername,
+			newPassword:   "",
+			expectedError: "account 'newPassword' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			requestPath     string
+			requestPassword string
+		)
+
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			account: func(r *http.Request) {
+				requestPath = r.URL.Path
+
+				var body struct{ Password string }
+
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				requestPassword = body.Password
+			},
+			overrides: testCase.overrides,
+		})
+
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		err := bmc.ChangeAccountPassword(testCase.username, testCase.newPassword)
+
+		redfishServer.Close()
+
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+			assert.Empty(t, requestPath)
+			assert.Equal(t, defaultPassword, bmc.redfishUser.Password)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedPath, requestPath)
+		assert.Equal(t, testCase.newPassword, requestPassword)
+
+		if testCase.updatesBMCUser {
+			assert.Equal(t, testCase.newPassword, bmc.redfishUser.Password)
+		} else {
+			assert.Equal(t, defaultPassword, bmc.redfishUser.Password)
+		}
+	}
+}
+
+func TestBMCListAccounts(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	accounts, err := bmc.ListAccounts()
+	assert.NoError(t, err)
+
+	if assert.Len(t, accounts, 2) {
+		assert.Equal(t, defaultUsername, accounts[0].UserName)
+		assert.Equal(t, "Administrator", accounts[0].RoleID)
+		assert.True(t, accounts[0].Enabled)
+		assert.Empty(t, accounts[0].Password)
+		assert.Equal(t, "operator", accounts[1].UserName)
+		assert.Equal(t, "Operator", accounts[1].RoleID)
+	}
+
+	// Check that a missing AccountService is reported.
+	missingServiceServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/AccountService": func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+		},
+	})
+	defer missingServiceServer.Close()
+
+	host = strings.Split(missingServiceServer.URL, "//")[1]
+
+	_, err = New(host).WithRedfishUser(defaultUsername, defaultPassword).ListAccounts()
+	assert.ErrorContains(t, err, "failed to get redfish account service")
+
+	// Check that a service root without an AccountService link is reported.
+	noLinkServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/": func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(strings.Replace(
+					redfishRootJSONResponse, `"AccountService"`, `"AccountServiceRemoved"`, 1)))
+			},
+		},
+	})
+	defer noLinkServer.Close()
+
+	host = strings.Split(noLinkServer.URL, "//")[1]
+
+	_, err = New(host).WithRedfishUser(defaultUsername, defaultPassword).ListAccounts()
+	assert.EqualError(t, err,
+		"failed to get redfish account service: service root does not link to an account service")
+
+	// Check that the Redfish user is required.
+	_, err = New(host).ListAccounts()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCWithSSHHostKeyCallback(t *testing.T) {
+	testCases := []struct {
+		name            string
+		hostKeyCallback ssh.HostKeyCallback
+		expectedErrMsg  string
+	}{
+		{
+			name:            "everything alright",
+			hostKeyCallback: ssh.InsecureIgnoreHostKey(),
+			expectedErrMsg:  "",
+		},
+		{
+			name:            "nil callback",
+			hostKeyCallback: nil,
+			expectedErrMsg:  "ssh 'hostKeyCallback' cannot be nil",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bmc := New(defaultHost).WithSSHHostKeyCallback(testCase.hostKeyCallback)
+
+			assert.Equal(t, testCase.expectedErrMsg, bmc.errorMsg)
+
+			if testCase.expectedErrMsg == "" {
+				assert.NotNil(t, bmc.sshHostKeyCallback)
+			}
+		})
+	}
+}
+
+func TestBMCCreateCLISSHSessionHostKeyCallback(t *testing.T) {
+	host, port := createFakeSSHServer(t)
+
+	// The callback rejecting the host key should cause the connection to fail.
+	var callbackCalled bool
+
+	bmc := New(host).
+		WithSSHUser(defaultUsername, defaultPassword).
+		WithSSHPort(port).
+		WithSSHHostKeyCallback(func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			callbackCalled = true
+
+			return fmt.Errorf("host key rejected")
+		})
+
+	session, err := bmc.CreateCLISSHSession()
+	assert.Nil(t, session)
+	assert.ErrorContains(t, err, "host key rejected")
+	assert.True(t, callbackCalled)
+
+	// Without a callback, the host key is not verified and the session is created.
+	bmc = New(host).WithSSHUser(defaultUsername, defaultPassword).WithSSHPort(port)
+
+	session, err = bmc.CreateCLISSHSession()
+	assert.NoError(t, err)
+	assert.NotNil(t, session)
+
+	_ = session.Close()
+}
+
 func TestBMCSystemManufacturer(t *testing.T) {
 	respCallbacks := redfishAPIResponseCallbacks{}
 
@@ -421,22 +962,247 @@ func TestBMCManufacturerTimeout(t *testing.T) {
 	assert.NotEmptyf(t, match, "Error did not match. Expected regexp: %v, Got: %s", expectedTimeoutErrMsgRegex, errMsg)
 }
 
-func TestBMCSecureBootStatus(t *testing.T) {
-	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+func TestBMCSystemManufacturerContext(t *testing.T) {
+	respCallbacks := redfishAPIResponseCallbacks{}
 
-	host := strings.Split(redfishServer.URL, "//")[1]
-	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+	// The session endpoint is delayed well beyond the cancellation below but within the configured timeout, so only
+	// the caller's context can abort the request.
+	respCallbacks.sessions = getDelayResponseCallbackFn(t, 200*time.Millisecond)
 
-	// Get twice the SecureBoot status, which should be false.
-	expectedSecureBootStatus := false
+	redfishServer := createFakeRedfishLocalServer(false, respCallbacks)
+	defer redfishServer.Close()
 
-	sbStatus, err := bmc.IsSecureBootEnabled()
-	assert.NoError(t, err, "Failed to get secure boot status")
-	assert.Equal(t, expectedSecureBootStatus, sbStatus)
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword).WithRedfishTimeout(time.Second)
 
-	// Try getting the secureboot status from a non-existent system (e.g index 2)
-	expectedErrMsg := fmt.Sprintf(secureBootFailFmt, 2)
-	bmc = bmc.WithRedfishSystemIndex(2)
+	manufacturer, err := bmc.SystemManufacturerContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "Dell Inc.", manufacturer)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = bmc.SystemManufacturerContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBMCRedfishConnectRetries(t *testing.T) {
+	testCases := []struct {
+		name             string
+		retries          int
+		failedConnects   int
+		failureStatus    int
+		expectedAttempts int32
+		expectedErrMsg   string
+	}{
+		{
+			name:             "single attempt by default",
+			retries:          0,
+			failedConnects:   1,
+			failureStatus:    http.StatusServiceUnavailable,
+			expectedAttempts: 1,
+			expectedErrMsg:   "redfish connection error: failed to connect to redfish endpoint",
+		},
+		{
+			name:             "succeeds after retries",
+			retries:          2,
+			failedConnects:   2,
+			failureStatus:    http.StatusServiceUnavailable,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "fails after retries",
+			retries:          1,
+			failedConnects:   2,
+			failureStatus:    http.StatusServiceUnavailable,
+			expectedAttempts: 2,
+			expectedErrMsg:   "redfish connection error: failed to connect to redfish endpoint",
+		},
+		{
+			name:             "rejected credentials are not retried",
+			retries:          2,
+			failedConnects:   1,
+			failureStatus:    http.StatusUnauthorized,
+			expectedAttempts: 1,
+			expectedErrMsg:   "redfish connection error: failed to connect to redfish endpoint",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var attempts atomic.Int32
+
+			redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+				overrides: map[string]http.HandlerFunc{
+					"/redfish/v1/SessionService/Sessions": func(w http.ResponseWriter, r *http.Request) {
+						if r.Method == http.MethodPost && attempts.Add(1) <= int32(testCase.failedConnects) {
+							w.WriteHeader(testCase.failureStatus)
+
+							return
+						}
+
+						_, _ = w.Write([]byte("{}"))
+					},
+				},
+			})
+			defer redfishServer.Close()
+
+			host := strings.Split(redfishServer.URL, "//")[1]
+			bmc := New(host).
+				WithRedfishUser(defaultUsername, defaultPassword).
+				WithRedfishConnectRetries(testCase.retries, 10*time.Millisecond)
+
+			manufacturer, err := bmc.SystemManufacturer()
+			assert.Equal(t, testCase.expectedAttempts, attempts.Load())
+
+			if testCase.expectedErrMsg != "" {
+				assert.ErrorContains(t, err, testCase.expectedErrMsg)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "Dell Inc.", manufacturer)
+		})
+	}
+}
+
+func TestBMCConnect(t *testing.T) {
+	var sessionsCreated atomic.Int32
+
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		sessions: func(r *http.Request) {
+			if r.Method == http.MethodPost {
+				sessionsCreated.Add(1)
+			}
+		},
+	})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	// Without a cached session, each call logs in separately.
+	_, err := bmc.SystemManufacturer()
+	assert.NoError(t, err)
+	_, err = bmc.GetPowerState()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), sessionsCreated.Load())
+
+	// Connecting twice only logs in once and the session is reused by concurrent calls.
+	sessionsCreated.Store(0)
+
+	assert.NoError(t, bmc.Connect())
+	assert.NoError(t, bmc.Connect())
+
+	var waitGroup sync.WaitGroup
+
+	for range 5 {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			powerState, err := bmc.GetPowerState()
+			assert.NoError(t, err)
+			assert.Equal(t, redfish.OnPowerState, powerState)
+		}()
+	}
+
+	waitGroup.Wait()
+
+	_, err = bmc.SystemManufacturer()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), sessionsCreated.Load())
+
+	// After disconnecting, calls go back to logging in separately.
+	sessionsCreated.Store(0)
+
+	assert.NoError(t, bmc.Disconnect())
+	assert.NoError(t, bmc.Disconnect())
+	assert.Nil(t, bmc.redfishClient)
+	assert.Nil(t, bmc.redfishCancel)
+
+	_, err = bmc.SystemManufacturer()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), sessionsCreated.Load())
+
+	// Check that the Redfish user is required.
+	err = New(host).Connect()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+
+	// Check that a nil BMC returns an error rather than panicking.
+	var nilBMC *BMC
+	assert.EqualError(t, nilBMC.Disconnect(), "error: received nil bmc")
+}
+
+func TestBMCConnectTimeout(t *testing.T) {
+	var delaySystem atomic.Bool
+
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		sessions: getDelayResponseCallbackFn(t, 200*time.Millisecond),
+		system: func(*http.Request) {
+			if delaySystem.Load() {
+				time.Sleep(200 * time.Millisecond)
+			}
+		},
+	})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+
+	// Logging in is bounded by the Redfish timeout.
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword).WithRedfishTimeout(100 * time.Millisecond)
+	err := bmc.Connect()
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, bmc.redfishClient)
+
+	bmc = New(host).WithRedfishUser(defaultUsername, defaultPassword).WithRedfishTimeout(time.Second)
+	assert.NoError(t, bmc.Connect())
+
+	defer func() {
+		assert.NoError(t, bmc.Disconnect())
+	}()
+
+	delaySystem.Store(true)
+
+	// Requests on the cached session are bounded by the caller's context.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = bmc.SystemManufacturerContext(ctx)
+	assert.ErrorContains(t, err, context.DeadlineExceeded.Error())
+
+	// They are also bounded by the Redfish timeout.
+	bmc.timeOuts.Redfish = 100 * time.Millisecond
+
+	_, err = bmc.SystemManufacturer()
+	assert.ErrorContains(t, err, context.DeadlineExceeded.Error())
+
+	// The session can still be used once the request is no longer delayed.
+	delaySystem.Store(false)
+
+	manufacturer, err := bmc.SystemManufacturer()
+	assert.NoError(t, err)
+	assert.Equal(t, "Dell Inc.", manufacturer)
+}
+
+func TestBMCSecureBootStatus(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	// Get twice the SecureBoot status, which should be false.
+	expectedSecureBootStatus := false
+
+	sbStatus, err := bmc.IsSecureBootEnabled()
+	assert.NoError(t, err, "Failed to get secure boot status")
+	assert.Equal(t, expectedSecureBootStatus, sbStatus)
+
+	// Try getting the secureboot status from a non-existent system (e.g index 2)
+	expectedErrMsg := fmt.Sprintf(secureBootFailFmt, 2)
+	bmc = bmc.WithRedfishSystemIndex(2)
 
 	_, err = bmc.IsSecureBootEnabled()
 	assert.EqualError(t, err, expectedErrMsg)
@@ -515,88 +1281,1742 @@ func TestBMCSecureBootDisable(t *testing.T) {
 	if err.Error() != expectedErrMsg {
 		t.Errorf("Unexpected error when getting manufacturer of non-existent system. Want: %v, Got: %v", expectedErrMsg, err)
 	}
-}
+}
+
+func TestBMCSystemResetAction(t *testing.T) {
+	resetActions := []redfish.ResetType{
+		redfish.OnResetType,
+		redfish.ForceOnResetType,
+		redfish.ForceOffResetType,
+		redfish.ForceRestartResetType,
+		redfish.GracefulRestartResetType,
+		redfish.GracefulShutdownResetType,
+		redfish.PushPowerButtonResetType,
+		redfish.NmiResetType,
+		redfish.PauseResetType,
+		redfish.ResumeResetType,
+		redfish.SuspendResetType,
+	}
+
+	for _, resetAction := range resetActions {
+		testResetAction(t, string(resetAction), func(bmc *BMC) error {
+			return bmc.SystemResetAction(resetAction)
+		})
+	}
+}
+
+func TestBMCSystemForceReset(t *testing.T) {
+	testResetAction(t, "ForceReset", func(bmc *BMC) error {
+		return bmc.SystemForceReset()
+	})
+}
+
+func TestBMCSystemGracefulShutdown(t *testing.T) {
+	testResetAction(t, "GracefulShutdown", func(bmc *BMC) error {
+		return bmc.SystemGracefulShutdown()
+	})
+}
+
+func TestBMCSystemPowerOn(t *testing.T) {
+	testResetAction(t, "PowerOn", func(bmc *BMC) error {
+		return bmc.SystemPowerOn()
+	})
+}
+
+func TestBMCSystemPowerOff(t *testing.T) {
+	testResetAction(t, "PowerOff", func(bmc *BMC) error {
+		return bmc.SystemPowerOff()
+	})
+
+	// Check that the ForceOff reset type is requested rather than GracefulShutdown.
+	var requestedResetType redfish.ResetType
+
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset": func(
+				w http.ResponseWriter, r *http.Request) {
+				var body struct{ ResetType redfish.ResetType }
+
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				requestedResetType = body.ResetType
+
+				w.WriteHeader(http.StatusNoContent)
+			},
+		},
+	})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+
+	err := New(host).WithRedfishUser(defaultUsername, defaultPassword).SystemPowerOff()
+	assert.NoError(t, err)
+	assert.Equal(t, redfish.ForceOffResetType, requestedResetType)
+}
+
+func TestBMCSystemPowerCycle(t *testing.T) {
+	testResetAction(t, "PowerCycle", func(bmc *BMC) error {
+		return bmc.SystemPowerCycle()
+	})
+}
+
+//nolint:funlen
+func TestBMCGracefulRebootAndWait(t *testing.T) {
+	defaultPollInterval := powerOnPollInterval
+	powerOnPollInterval = 10 * time.Millisecond
+
+	defer func() {
+		powerOnPollInterval = defaultPollInterval
+	}()
+
+	testCases := []struct {
+		name                string
+		unsupportedTypes    []redfish.ResetType
+		restartStaysOn      bool
+		restartKeepsPowerOn bool
+		noBootProgress      bool
+		initialBootProgress BootProgressTypes
+		expectedResetTypes  []redfish.ResetType
+		expectedError       string
+		expectedErrorIs     error
+	}{
+		{
+			name:               "graceful restart supported",
+			expectedResetTypes: []redfish.ResetType{redfish.GracefulRestartResetType},
+		},
+		{
+			name:             "graceful restart not supported",
+			unsupportedTypes: []redfish.ResetType{redfish.GracefulRestartResetType},
+			expectedResetTypes: []redfish.ResetType{
+				redfish.GracefulShutdownResetType, redfish.OnResetType},
+		},
+		{
+			name:                "warm reboot keeps system powered on",
+			restartKeepsPowerOn: true,
+			expectedResetTypes:  []redfish.ResetType{redfish.GracefulRestartResetType},
+		},
+		{
+			name:                "initial boot progress not OSRunning",
+			restartKeepsPowerOn: true,
+			initialBootProgress: OSBootStartedBootProgressTypes,
+			expectedResetTypes:  []redfish.ResetType{redfish.GracefulRestartResetType},
+		},
+		{
+			name:           "boot progress not reported",
+			noBootProgress: true,
+			expectedResetTypes: []redfish.ResetType{
+				redfish.GracefulShutdownResetType, redfish.OnResetType},
+		},
+		{
+			name:                "boot progress not reported and power stays on during restart",
+			noBootProgress:      true,
+			restartKeepsPowerOn: true,
+			expectedResetTypes: []redfish.ResetType{
+				redfish.GracefulShutdownResetType, redfish.OnResetType},
+		},
+		{
+			name:               "boot progress not reported and graceful shutdown not supported",
+			noBootProgress:     true,
+			unsupportedTypes:   []redfish.ResetType{redfish.GracefulShutdownResetType},
+			expectedResetTypes: []redfish.ResetType{redfish.GracefulRestartResetType},
+		},
+		{
+			name:               "system never goes down",
+			restartStaysOn:     true,
+			expectedResetTypes: []redfish.ResetType{redfish.GracefulRestartResetType},
+			expectedErrorIs:    context.DeadlineExceeded,
+		},
+		{
+			name: "no graceful reset type supported",
+			unsupportedTypes: []redfish.ResetType{
+				redfish.GracefulRestartResetType, redfish.GracefulShutdownResetType},
+			expectedError: "unable to perform graceful reboot",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var (
+				resetTypes       []redfish.ResetType
+				powerState       = redfish.OnPowerState
+				bootProgress     = OSRunningBootProgressTypes
+				downRequestsLeft int
+			)
+
+			if testCase.initialBootProgress != "" {
+				bootProgress = testCase.initialBootProgress
+			}
+
+			upBootProgress := bootProgress
+
+			redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+				overrides: map[string]http.HandlerFunc{
+					"/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset": func(
+						w http.ResponseWriter, r *http.Request) {
+						var body struct{ ResetType redfish.ResetType }
+
+						_ = json.NewDecoder(r.Body).Decode(&body)
+						resetTypes = append(resetTypes, body.ResetType)
+
+						switch body.ResetType {
+						case redfish.GracefulRestartResetType:
+							// The system reports being down for two polls, each fetching the system twice, before
+							// coming back up.
+							if !testCase.restartStaysOn {
+								bootProgress = SystemHardwareInitializationCompleteBootProgressTypes
+								downRequestsLeft = 4
+
+								if !testCase.restartKeepsPowerOn {
+									powerState = redfish.OffPowerState
+								}
+							}
+						case redfish.GracefulShutdownResetType:
+							powerState = redfish.OffPowerState
+							bootProgress = NoneBootProgressTypes
+						case redfish.OnResetType:
+							powerState = redfish.OnPowerState
+							bootProgress = OSRunningBootProgressTypes
+						}
+
+						w.WriteHeader(http.StatusNoContent)
+					},
+					"/redfish/v1/Systems/System.Embedded.1": func(w http.ResponseWriter, r *http.Request) {
+						response := strings.Replace(
+							redfishSystemJSONResponse, `"PowerState": "On"`, fmt.Sprintf(`"PowerState": "%s"`, powerState), 1)
+
+						for _, unsupportedType := range testCase.unsupportedTypes {
+							response = strings.Replace(response, fmt.Sprintf(`"%s",`, unsupportedType), "", 1)
+						}
+
+						replacement := fmt.Sprintf(`"LastState": "%s"`, bootProgress)
+						if testCase.noBootProgress {
+							replacement = `"LastStateRemoved": null`
+						}
+
+						response = strings.Replace(response, `"LastState": "OSRunning"`, replacement, 1)
+
+						if downRequestsLeft > 0 {
+							downRequestsLeft--
+
+							if downRequestsLeft == 0 {
+								powerState = redfish.OnPowerState
+								bootProgress = upBootProgress
+							}
+						}
+
+						_, _ = w.Write([]byte(response))
+					},
+				},
+			})
+			defer redfishServer.Close()
+
+			host := strings.Split(redfishServer.URL, "//")[1]
+			bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+			err := bmc.GracefulRebootAndWait(200 * time.Millisecond)
+			assert.Equal(t, testCase.expectedResetTypes, resetTypes)
+
+			switch {
+			case testCase.expectedErrorIs != nil:
+				assert.ErrorIs(t, err, testCase.expectedErrorIs)
+			case testCase.expectedError != "":
+				assert.ErrorContains(t, err, testCase.expectedError)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// Check that the Redfish user is required.
+	err := New("1.2.3.4").GracefulRebootAndWait(time.Second)
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCPowerOnAndWaitForOS(t *testing.T) {
+	defaultPollInterval := powerOnPollInterval
+	defaultSettleTime := powerOnSettleTime
+	powerOnPollInterval = 10 * time.Millisecond
+	powerOnSettleTime = 200 * time.Millisecond
+
+	defer func() {
+		powerOnPollInterval = defaultPollInterval
+		powerOnSettleTime = defaultSettleTime
+	}()
+
+	testCases := []struct {
+		name           string
+		bootProgresses []string
+		powerOff       bool
+		expectedError  error
+	}{
+		{
+			name:           "boot progress advances to OSRunning",
+			bootProgresses: []string{"None", "SystemHardwareInitializationComplete", "OSBootStarted", "OSRunning"},
+		},
+		{
+			name:           "boot progress not reported",
+			bootProgresses: []string{""},
+		},
+		{
+			name:           "boot progress stops before OSRunning",
+			bootProgresses: []string{"OSBootStarted"},
+		},
+		{
+			name:           "system never powers on",
+			bootProgresses: []string{"OSBootStarted"},
+			powerOff:       true,
+			expectedError:  context.DeadlineExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var (
+				resetRequested bool
+				systemRequests int
+			)
+
+			redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+				overrides: map[string]http.HandlerFunc{
+					"/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset": func(
+						w http.ResponseWriter, r *http.Request) {
+						resetRequested = true
+
+						w.WriteHeader(http.StatusNoContent)
+					},
+					"/redfish/v1/Systems/System.Embedded.1": func(w http.ResponseWriter, r *http.Request) {
+						// Boot progress only advances once the system has been powered on. Each poll fetches the
+						// system twice, so the index advances every other request.
+						bootProgress := testCase.bootProgresses[0]
+						if resetRequested {
+							index := min(systemRequests/2, len(testCase.bootProgresses)-1)
+							bootProgress = testCase.bootProgresses[index]
+							systemRequests++
+						}
+
+						replacement := fmt.Sprintf(`"LastState": "%s"`, bootProgress)
+						if bootProgress == "" {
+							replacement = `"LastStateRemoved": null`
+						}
+
+						response := strings.Replace(
+							redfishSystemJSONResponse, `"LastState": "OSRunning"`, replacement, 1)
+						if testCase.powerOff {
+							response = strings.Replace(response, `"PowerState": "On"`, `"PowerState": "Off"`, 1)
+						}
+
+						_, _ = w.Write([]byte(response))
+					},
+				},
+			})
+			defer redfishServer.Close()
+
+			host := strings.Split(redfishServer.URL, "//")[1]
+			bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+			err := bmc.PowerOnAndWaitForOS(time.Second)
+			assert.True(t, resetRequested)
+
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+
+	// Check that the Redfish user is required.
+	err := New("1.2.3.4").PowerOnAndWaitForOS(time.Second)
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCManagerReset(t *testing.T) {
+	testCases := []struct {
+		name          string
+		resetFunction func(bmc *BMC) error
+		expectedType  redfish.ResetType
+		expectedError string
+	}{
+		{
+			name:          "graceful restart",
+			resetFunction: (*BMC).ManagerGracefulRestart,
+			expectedType:  redfish.GracefulRestartResetType,
+		},
+		{
+			name:          "force restart",
+			resetFunction: (*BMC).ManagerForceRestart,
+			expectedType:  redfish.ForceRestartResetType,
+		},
+		{
+			name: "unsupported reset type",
+			resetFunction: func(bmc *BMC) error {
+				return bmc.ManagerReset(redfish.PowerCycleResetType)
+			},
+			expectedError: "failed to perform manager reset action PowerCycle: " +
+				"reset type 'PowerCycle' is not supported by this manager",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var requestedType redfish.ResetType
+
+			redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+				overrides: map[string]http.HandlerFunc{
+					"/redfish/v1/Managers/iDRAC.Embedded.1/Actions/Manager.Reset": func(
+						w http.ResponseWriter, r *http.Request) {
+						var body struct{ ResetType redfish.ResetType }
+
+						_ = json.NewDecoder(r.Body).Decode(&body)
+						requestedType = body.ResetType
+
+						w.WriteHeader(http.StatusNoContent)
+					},
+				},
+			})
+			defer redfishServer.Close()
+
+			host := strings.Split(redfishServer.URL, "//")[1]
+			bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+			err := testCase.resetFunction(bmc)
+			assert.Equal(t, testCase.expectedType, requestedType)
+
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+
+	// Check that the Redfish user is required.
+	err := New("1.2.3.4").ManagerGracefulRestart()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCManagerFirmwareVersion(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	firmwareVersion, err := bmc.ManagerFirmwareVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "7.00.00.171", firmwareVersion)
+
+	// Check that an error is returned when the system is not managed by any manager.
+	unmanagedServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/Systems/System.Embedded.1": func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(strings.Replace(
+					redfishSystemJSONResponse, `"ManagedBy": [`, `"ManagedByRemoved": [`, 1)))
+			},
+		},
+	})
+	defer unmanagedServer.Close()
+
+	host = strings.Split(unmanagedServer.URL, "//")[1]
+
+	_, err = New(host).WithRedfishUser(defaultUsername, defaultPassword).ManagerFirmwareVersion()
+	assert.EqualError(t, err,
+		"failed to get redfish manager of system System.Embedded.1: system is not managed by any manager")
+
+	// Check that the Redfish user is required.
+	_, err = New(host).ManagerFirmwareVersion()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+//nolint:funlen
+func TestBMCBootFromVirtualMedia(t *testing.T) {
+	const testImage = "http://example.com/test.iso"
+
+	defaultPollInterval := powerOnPollInterval
+	powerOnPollInterval = 10 * time.Millisecond
+
+	defer func() {
+		powerOnPollInterval = defaultPollInterval
+	}()
+
+	testCases := []struct {
+		name             string
+		timeout          time.Duration
+		failedRequest    string
+		noBootProgress   bool
+		neverRestarts    bool
+		expectedRequests []string
+		expectedError    string
+		expectedErrorIs  error
+	}{
+		{
+			name:             "boot without waiting",
+			timeout:          0,
+			expectedRequests: []string{"InsertMedia", "BootOverride", "ForceRestart"},
+		},
+		{
+			name:             "boot and wait for restart",
+			timeout:          time.Second,
+			expectedRequests: []string{"InsertMedia", "BootOverride", "ForceRestart"},
+		},
+		{
+			name:             "boot progress not reported",
+			timeout:          time.Second,
+			noBootProgress:   true,
+			expectedRequests: []string{"InsertMedia", "BootOverride", "ForceRestart"},
+		},
+		{
+			name:             "system never restarts",
+			timeout:          200 * time.Millisecond,
+			neverRestarts:    true,
+			expectedRequests: []string{"InsertMedia", "BootOverride", "ForceRestart", "EjectMedia"},
+			expectedErrorIs:  context.DeadlineExceeded,
+		},
+		{
+			name:             "insert media fails",
+			timeout:          time.Second,
+			failedRequest:    "InsertMedia",
+			expectedRequests: []string{"InsertMedia"},
+			expectedError:    "failed to insert virtual media",
+		},
+		{
+			name:             "boot override fails",
+			timeout:          time.Second,
+			failedRequest:    "BootOverride",
+			expectedRequests: []string{"InsertMedia", "BootOverride", "EjectMedia"},
+			expectedError:    "failed to set boot source override",
+		},
+		{
+			name:             "reset fails",
+			timeout:          time.Second,
+			failedRequest:    "ForceRestart",
+			expectedRequests: []string{"InsertMedia", "BootOverride", "ForceRestart", "EjectMedia"},
+			expectedError:    "failed to perform ForceRestart system reset",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var (
+				requests         []string
+				inserted         bool
+				downRequestsLeft int
+			)
+
+			// recordRequest records the request and writes a failure if it is the one that should fail.
+			recordRequest := func(w http.ResponseWriter, request string) bool {
+				requests = append(requests, request)
+
+				if request == testCase.failedRequest {
+					w.WriteHeader(http.StatusInternalServerError)
+
+					return false
+				}
+
+				return true
+			}
+
+			redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+				overrides: map[string]http.HandlerFunc{
+					"/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/CD": func(w http.ResponseWriter, r *http.Request) {
+						response := redfishVirtualMediaCDJSONResponse
+						if inserted {
+							response = strings.Replace(response, `"Inserted": false`, `"Inserted": true`, 1)
+						}
+
+						_, _ = w.Write([]byte(response))
+					},
+					"/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/CD/Actions/VirtualMedia.InsertMedia": func(
+						w http.ResponseWriter, r *http.Request) {
+						inserted = recordRequest(w, "InsertMedia")
+						if inserted {
+							w.WriteHeader(http.StatusNoContent)
+						}
+					},
+					"/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/CD/Actions/VirtualMedia.EjectMedia": func(
+						w http.ResponseWriter, r *http.Request) {
+						if recordRequest(w, "EjectMedia") {
+							inserted = false
+
+							w.WriteHeader(http.StatusNoContent)
+						}
+					},
+					"/redfish/v1/Systems/System.Embedded.1": func(w http.ResponseWriter, r *http.Request) {
+						if r.Method != http.MethodPatch {
+							// After the reset, the system reports booting for two polls, each fetching the system
+							// twice, before the OS is running again.
+							replacement := `"LastState": "OSRunning"`
+
+							switch {
+							case testCase.noBootProgress:
+								replacement = `"LastStateRemoved": null`
+							case downRequestsLeft > 0:
+								replacement = `"LastState": "None"`
+								downRequestsLeft--
+							}
+
+							_, _ = w.Write([]byte(strings.Replace(
+								redfishSystemJSONResponse, `"LastState": "OSRunning"`, replacement, 1)))
+
+							return
+						}
+
+						var body struct{ Boot redfish.Boot }
+
+						_ = json.NewDecoder(r.Body).Decode(&body)
+						assert.Equal(t, redfish.CdBootSourceOverrideTarget, body.Boot.BootSourceOverrideTarget)
+						assert.Equal(t, redfish.OnceBootSourceOverrideEnabled, body.Boot.BootSourceOverrideEnabled)
+
+						if recordRequest(w, "BootOverride") {
+							w.WriteHeader(http.StatusNoContent)
+						}
+					},
+					"/redfish/v1/Systems/System.Embedded.1/Actions/ComputerSystem.Reset": func(
+						w http.ResponseWriter, r *http.Request) {
+						var body struct{ ResetType redfish.ResetType }
+
+						_ = json.NewDecoder(r.Body).Decode(&body)
+
+						if recordRequest(w, string(body.ResetType)) {
+							if !testCase.neverRestarts {
+								downRequestsLeft = 4
+							}
+
+							w.WriteHeader(http.StatusNoContent)
+						}
+					},
+				},
+			})
+			defer redfishServer.Close()
+
+			host := strings.Split(redfishServer.URL, "//")[1]
+			bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+			err := bmc.BootFromVirtualMedia(testImage, testCase.timeout)
+			assert.Equal(t, testCase.expectedRequests, requests)
+
+			failed := testCase.expectedError != "" || testCase.expectedErrorIs != nil
+			assert.False(t, inserted && failed, "virtual media was not ejected after failure")
+
+			switch {
+			case testCase.expectedErrorIs != nil:
+				assert.ErrorIs(t, err, testCase.expectedErrorIs)
+
+				return
+			case testCase.expectedError != "":
+				assert.ErrorContains(t, err, testCase.expectedError)
+
+				return
+			}
+
+			if testCase.timeout > 0 && !testCase.noBootProgress {
+				assert.Zero(t, downRequestsLeft, "system restart was not waited for")
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+
+	// Check that the Redfish user is required.
+	err := New("1.2.3.4").BootFromVirtualMedia(testImage, time.Second)
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCSystemPowerState(t *testing.T) {
+	// Create fake redfish endpoint.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	const expectedPowerState = "On"
+
+	powerState, err := bmc.SystemPowerState()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPowerState, powerState)
+}
+
+func TestBMCGetPowerState(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	powerState, err := bmc.GetPowerState()
+	assert.NoError(t, err)
+	assert.Equal(t, redfish.OnPowerState, powerState)
+
+	// Try getting the power state of a non-existent system (e.g. index 1).
+	const expectedErrMsg = "failed to get redfish system: invalid system index 1 (base-index=0, num systems=1)"
+
+	_, err = bmc.WithRedfishSystemIndex(1).GetPowerState()
+	assert.EqualError(t, err, expectedErrMsg)
+
+	// Check that the Redfish user is required.
+	_, err = New(host).GetPowerState()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCGetSystemHealth(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	health, state, err := bmc.GetSystemHealth()
+	assert.NoError(t, err)
+	assert.Equal(t, common.OKHealth, health)
+	assert.Equal(t, common.EnabledState, state)
+
+	// Check that a critical health rollup is reported.
+	criticalServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/Systems/System.Embedded.1": func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(strings.Replace(redfishSystemJSONResponse,
+					`"HealthRollup": "OK",
+      "State": "Enabled"
+    },
+    "Storage"`,
+					`"HealthRollup": "Critical",
+      "State": "Enabled"
+    },
+    "Storage"`, 1)))
+			},
+		},
+	})
+	defer criticalServer.Close()
+
+	host = strings.Split(criticalServer.URL, "//")[1]
+
+	health, state, err = New(host).WithRedfishUser(defaultUsername, defaultPassword).GetSystemHealth()
+	assert.NoError(t, err)
+	assert.Equal(t, common.CriticalHealth, health)
+	assert.Equal(t, common.EnabledState, state)
+
+	// Try getting the health of a non-existent system (e.g. index 1).
+	const expectedErrMsg = "failed to get redfish system: invalid system index 1 (base-index=0, num systems=1)"
+
+	_, _, err = bmc.WithRedfishSystemIndex(1).GetSystemHealth()
+	assert.EqualError(t, err, expectedErrMsg)
+
+	// Check that the Redfish user is required.
+	_, _, err = New(host).GetSystemHealth()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCSystemSummary(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	processorSummary, memorySummary, err := bmc.SystemSummary()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, processorSummary.Count)
+	assert.Equal(t, "Intel(R) Xeon(R) Gold 6330N CPU @ 2.20GHz", processorSummary.Model)
+	assert.Equal(t, float32(256), memorySummary.TotalSystemMemoryGiB)
+
+	// Try getting the summary of a non-existent system (e.g. index 1).
+	const expectedErrMsg = "failed to get redfish system: invalid system index 1 (base-index=0, num systems=1)"
+
+	_, _, err = bmc.WithRedfishSystemIndex(1).SystemSummary()
+	assert.EqualError(t, err, expectedErrMsg)
+
+	// Check that the Redfish user is required.
+	_, _, err = New(host).SystemSummary()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCGetBootProgress(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	bootProgress, err := bmc.GetBootProgress()
+	assert.NoError(t, err)
+	assert.Equal(t, OSRunningBootProgressTypes, bootProgress)
+
+	// Check that a system not reporting BootProgress returns an empty value.
+	noBootProgressServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/Systems/System.Embedded.1": func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(strings.Replace(
+					redfishSystemJSONResponse, `"LastState": "OSRunning"`, `"LastStateRemoved": null`, 1)))
+			},
+		},
+	})
+	defer noBootProgressServer.Close()
+
+	host = strings.Split(noBootProgressServer.URL, "//")[1]
+
+	bootProgress, err = New(host).WithRedfishUser(defaultUsername, defaultPassword).GetBootProgress()
+	assert.NoError(t, err)
+	assert.Empty(t, bootProgress)
+
+	// Try getting the boot progress of a non-existent system (e.g. index 1).
+	const expectedErrMsg = "failed to get redfish system: invalid system index 1 (base-index=0, num systems=1)"
+
+	_, err = bmc.WithRedfishSystemIndex(1).GetBootProgress()
+	assert.EqualError(t, err, expectedErrMsg)
+
+	// Check that the Redfish user is required.
+	_, err = New(host).GetBootProgress()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCWaitForPowerState(t *testing.T) {
+	defaultPollInterval := powerOnPollInterval
+	powerOnPollInterval = 10 * time.Millisecond
+
+	defer func() {
+		powerOnPollInterval = defaultPollInterval
+	}()
+
+	var systemRequests atomic.Int32
+
+	// The system reports being powered off for the first two polls and powered on afterwards.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/Systems/System.Embedded.1": func(w http.ResponseWriter, r *http.Request) {
+				response := redfishSystemJSONResponse
+				if systemRequests.Add(1) <= 2 {
+					response = strings.Replace(response, `"PowerState": "On"`, `"PowerState": "Off"`, 1)
+				}
+
+				_, _ = w.Write([]byte(response))
+			},
+		},
+	})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	err := bmc.WaitForPowerState(redfish.OnPowerState, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), systemRequests.Load())
+
+	// The system stays powered on, so waiting for it to be off times out.
+	err = bmc.WaitForPowerState(redfish.OffPowerState, 100*time.Millisecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Check that the Redfish user is required.
+	err = New(host).WaitForPowerState(redfish.OnPowerState, time.Second)
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCTemperatures(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	temperatures, err := bmc.Temperatures()
+	assert.NoError(t, err)
+
+	if assert.Len(t, temperatures, 2) {
+		assert.Equal(t, "System Board Inlet Temp", temperatures[0].Name)
+		assert.Equal(t, float32(24), temperatures[0].ReadingCelsius)
+		assert.Equal(t, "CPU1 Temp", temperatures[1].Name)
+		assert.Equal(t, float32(41), temperatures[1].ReadingCelsius)
+	}
+
+	// Check that an error is returned when no chassis has a thermal link.
+	noThermalServer := createFakeRedfishThermalLessServer()
+	defer noThermalServer.Close()
+
+	host = strings.Split(noThermalServer.URL, "//")[1]
+
+	_, err = New(host).WithRedfishUser(defaultUsername, defaultPassword).Temperatures()
+	assert.EqualError(t, err,
+		"failed to get redfish thermal: failed to get thermal: no chassis with thermal link found")
+}
+
+func TestBMCFanSpeeds(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	fans, err := bmc.FanSpeeds()
+	assert.NoError(t, err)
+
+	if assert.Len(t, fans, 2) {
+		assert.Equal(t, "System Board Fan1A", fans[0].Name)
+		assert.Equal(t, float32(7560), fans[0].Reading)
+		assert.Equal(t, redfish.RPMReadingUnits, fans[0].ReadingUnits)
+	}
+
+	// Check that an error is returned when no chassis has a thermal link.
+	noThermalServer := createFakeRedfishThermalLessServer()
+	defer noThermalServer.Close()
+
+	host = strings.Split(noThermalServer.URL, "//")[1]
+
+	_, err = New(host).WithRedfishUser(defaultUsername, defaultPassword).FanSpeeds()
+	assert.EqualError(t, err,
+		"failed to get redfish thermal: failed to get thermal: no chassis with thermal link found")
+
+	// Check that the Redfish user is required.
+	_, err = New(host).FanSpeeds()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCGetIndicatorLED(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	indicatorLED, err := bmc.GetIndicatorLED()
+	assert.NoError(t, err)
+	assert.Equal(t, common.LitIndicatorLED, indicatorLED)
+
+	// Check that the Redfish user is required.
+	_, err = New(host).GetIndicatorLED()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCSetIndicatorLED(t *testing.T) {
+	testCases := []struct {
+		state         common.IndicatorLED
+		expectedError string
+	}{
+		{
+			state: common.BlinkingIndicatorLED,
+		},
+		{
+			state: common.OffIndicatorLED,
+		},
+		{
+			state:         common.UnknownIndicatorLED,
+			expectedError: `invalid indicator LED state "Unknown", must be one of [Lit Blinking Off]`,
+		},
+		{
+			state:         "",
+			expectedError: `invalid indicator LED state "", must be one of [Lit Blinking Off]`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		var patchedLED *common.IndicatorLED
+
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			chassisLED: func(r *http.Request) {
+				var body struct{ IndicatorLED common.IndicatorLED }
+
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				patchedLED = &body.IndicatorLED
+			},
+		})
+
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		err := bmc.SetIndicatorLED(testCase.state)
+
+		redfishServer.Close()
+
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+			assert.Nil(t, patchedLED)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+
+		if assert.NotNil(t, patchedLED) {
+			assert.Equal(t, testCase.state, *patchedLED)
+		}
+	}
+}
+
+func TestBMCGetChassisIntrusionStatus(t *testing.T) {
+	testCases := []struct {
+		intrusionSensor string
+		expectedStatus  redfish.IntrusionSensor
+		expectedError   string
+	}{
+		{
+			intrusionSensor: "Normal",
+			expectedStatus:  redfish.NormalIntrusionSensor,
+		},
+		{
+			intrusionSensor: "HardwareIntrusion",
+			expectedStatus:  redfish.HardwareIntrusionIntrusionSensor,
+		},
+		{
+			intrusionSensor: "",
+			expectedError: "failed to get redfish chassis with intrusion sensor: " +
+				"failed to get intrusion sensor: no chassis with physical security found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			overrides: map[string]http.HandlerFunc{
+				"/redfish/v1/Chassis/System.Embedded.1": func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(strings.Replace(redfishChassisJSONResponse,
+						`"IntrusionSensor": "Normal"`, fmt.Sprintf(`"IntrusionSensor": "%s"`, testCase.intrusionSensor), 1)))
+				},
+			},
+		})
+
+		host := strings.Split(redfishServer.URL, "//")[1]
+
+		intrusionStatus, err := New(host).WithRedfishUser(defaultUsername, defaultPassword).GetChassisIntrusionStatus()
+
+		redfishServer.Close()
+
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedStatus, intrusionStatus)
+	}
+
+	// Check that the Redfish user is required.
+	_, err := New("1.2.3.4").GetChassisIntrusionStatus()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCGetSystemEventLog(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	logEntries, err := bmc.GetSystemEventLog()
+	assert.NoError(t, err)
+
+	if assert.Len(t, logEntries, 2) {
+		assert.Equal(t, "1", logEntries[0].ID)
+		assert.Equal(t, redfish.OKEventSeverity, logEntries[0].Severity)
+		assert.Equal(t, "2", logEntries[1].ID)
+		assert.Equal(t, redfish.CriticalEventSeverity, logEntries[1].Severity)
+		assert.Equal(t, "The power input for power supply 1 is lost.", logEntries[1].Message)
+	}
+
+	// Check that the system is not needed when the SEL log service is on the manager.
+	noSystemServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/Systems/System.Embedded.1": func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		},
+	})
+	defer noSystemServer.Close()
+
+	host = strings.Split(noSystemServer.URL, "//")[1]
+
+	logEntries, err = New(host).WithRedfishUser(defaultUsername, defaultPassword).GetSystemEventLog()
+	assert.NoError(t, err)
+	assert.Len(t, logEntries, 2)
+
+	// Check that an error is returned when there is no SEL log service.
+	noSELServer := createFakeRedfishNoSELServer()
+	defer noSELServer.Close()
+
+	host = strings.Split(noSELServer.URL, "//")[1]
+
+	_, err = New(host).WithRedfishUser(defaultUsername, defaultPassword).GetSystemEventLog()
+	assert.EqualError(t, err,
+		"failed to get redfish SEL log service: failed to get SEL log service: no SEL log service found")
+}
+
+func TestBMCClearSystemEventLog(t *testing.T) {
+	var clearLogRequested bool
+
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		clearLog: func(r *http.Request) {
+			clearLogRequested = true
+		},
+	})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	err := bmc.ClearSystemEventLog()
+	assert.NoError(t, err)
+	assert.True(t, clearLogRequested)
+
+	// Check that an error is returned when there is no SEL log service.
+	noSELServer := createFakeRedfishNoSELServer()
+	defer noSELServer.Close()
+
+	host = strings.Split(noSELServer.URL, "//")[1]
+
+	err = New(host).WithRedfishUser(defaultUsername, defaultPassword).ClearSystemEventLog()
+	assert.EqualError(t, err,
+		"failed to get redfish SEL log service: failed to get SEL log service: no SEL log service found")
+
+	// Check that the Redfish user is required.
+	err = New(host).ClearSystemEventLog()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCFirmwareInventory(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	firmwareInventory, err := bmc.FirmwareInventory()
+	assert.NoError(t, err)
+
+	if assert.Len(t, firmwareInventory, 2) {
+		assert.Equal(t, "BIOS", firmwareInventory[0].Name)
+		assert.Equal(t, "1.13.2", firmwareInventory[0].Version)
+		assert.Equal(t, "Integrated Dell Remote Access Controller", firmwareInventory[1].Name)
+		assert.Equal(t, "7.00.00.171", firmwareInventory[1].Version)
+	}
+
+	// Check that a missing firmware inventory is reported.
+	missingInventoryServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/UpdateService/FirmwareInventory": func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+		},
+	})
+	defer missingInventoryServer.Close()
+
+	host = strings.Split(missingInventoryServer.URL, "//")[1]
+
+	_, err = New(host).WithRedfishUser(defaultUsername, defaultPassword).FirmwareInventory()
+	assert.ErrorContains(t, err, "failed to get redfish firmware inventory")
+
+	// Check that the Redfish user is required.
+	_, err = New(host).FirmwareInventory()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCStorageDrives(t *testing.T) {
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	drives, err := bmc.StorageDrives()
+	assert.NoError(t, err)
+
+	if assert.Len(t, drives, 3) {
+		assert.Equal(t, "Disk.Direct.0-0:AHCI.Embedded.1-1", drives[0].ID)
+		assert.Equal(t, int64(480103981056), drives[0].CapacityBytes)
+		assert.Equal(t, "Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1", drives[1].ID)
+		assert.Equal(t, int64(960197124096), drives[1].CapacityBytes)
+		assert.Equal(t, "Disk.Bay.1:Enclosure.Internal.0-1:RAID.Integrated.1-1", drives[2].ID)
+		assert.Equal(t, "X1A0A0AAAAAB", drives[2].SerialNumber)
+	}
+
+	// Check that the failing storage controller is identified.
+	missingDriveServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/Systems/System.Embedded.1/Storage/RAID.Integrated.1-1/Drives/" +
+				"Disk.Bay.1:Enclosure.Internal.0-1:RAID.Integrated.1-1": func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+		},
+	})
+	defer missingDriveServer.Close()
+
+	host = strings.Split(missingDriveServer.URL, "//")[1]
+
+	_, err = New(host).WithRedfishUser(defaultUsername, defaultPassword).StorageDrives()
+	assert.ErrorContains(t, err, "failed to get drives of redfish storage controller RAID.Integrated.1-1")
+
+	// Check that the Redfish user is required.
+	_, err = New(host).StorageDrives()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCSetBootSourceOverride(t *testing.T) {
+	testCases := []struct {
+		target        redfish.BootSourceOverrideTarget
+		enabled       redfish.BootSourceOverrideEnabled
+		expectedError string
+	}{
+		{
+			target:  redfish.PxeBootSourceOverrideTarget,
+			enabled: redfish.OnceBootSourceOverrideEnabled,
+		},
+		{
+			target:  redfish.CdBootSourceOverrideTarget,
+			enabled: redfish.ContinuousBootSourceOverrideEnabled,
+		},
+		{
+			target:        "",
+			enabled:       redfish.OnceBootSourceOverrideEnabled,
+			expectedError: "boot source override 'target' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var patchedBoot *redfish.Boot
+
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			overrides: map[string]http.HandlerFunc{
+				"/redfish/v1/Systems/System.Embedded.1": func(w http.ResponseWriter, r *http.Request) {
+					if r.Method == http.MethodPatch {
+						var body struct{ Boot redfish.Boot }
+
+						_ = json.NewDecoder(r.Body).Decode(&body)
+						patchedBoot = &body.Boot
+
+						w.WriteHeader(http.StatusNoContent)
+
+						return
+					}
+
+					_, _ = w.Write([]byte(redfishSystemJSONResponse))
+				},
+			},
+		})
+
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		err := bmc.SetBootSourceOverride(testCase.target, testCase.enabled)
+
+		redfishServer.Close()
+
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+			assert.Nil(t, patchedBoot)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+
+		if assert.NotNil(t, patchedBoot) {
+			assert.Equal(t, testCase.target, patchedBoot.BootSourceOverrideTarget)
+			assert.Equal(t, testCase.enabled, patchedBoot.BootSourceOverrideEnabled)
+			assert.Empty(t, patchedBoot.BootOrder)
+		}
+	}
+
+	// Check that the Redfish user is required.
+	err := New("1.2.3.4").SetBootSourceOverride(redfish.PxeBootSourceOverrideTarget, redfish.OnceBootSourceOverrideEnabled)
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCPowerUsage(t *testing.T) {
+	// Create a fake redfish api endpoint with secureBoot "disabled"
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	const expectedPowerUsage float32 = 360.0
+
+	power, err := bmc.PowerUsage()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedPowerUsage, power)
+}
+
+func TestBMCSamplePowerUsage(t *testing.T) {
+	var (
+		sessionsCreated atomic.Int32
+		powerRequests   atomic.Int32
+	)
+
+	// Each reading of the power usage is 10 watts higher than the last.
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		sessions: func(r *http.Request) {
+			if r.Method == http.MethodPost {
+				sessionsCreated.Add(1)
+			}
+		},
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/Chassis/System.Embedded.1/Power": func(w http.ResponseWriter, r *http.Request) {
+				powerConsumedWatts := 350 + 10*powerRequests.Add(1)
+
+				_, _ = w.Write([]byte(strings.Replace(redfishPowerJSONResponse,
+					`"PowerConsumedWatts": 360`, fmt.Sprintf(`"PowerConsumedWatts": %d`, powerConsumedWatts), 1)))
+			},
+		},
+	})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	powerUsages, err := bmc.SamplePowerUsage(3, 10*time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, []float32{360, 370, 380}, powerUsages)
+	assert.Equal(t, int32(1), sessionsCreated.Load())
+
+	_, err = bmc.SamplePowerUsage(0, 10*time.Millisecond)
+	assert.EqualError(t, err, "power usage 'samples' cannot be less than or equal to zero")
+
+	_, err = bmc.SamplePowerUsage(3, 0)
+	assert.EqualError(t, err, "power usage sample 'interval' cannot be less than or equal to zero")
+
+	// Check that the Redfish user is required.
+	_, err = New(host).SamplePowerUsage(3, 10*time.Millisecond)
+	assert.EqualError(t, err, "cannot access redfish with nil user")
+}
+
+func TestBMCGetPowerLimit(t *testing.T) {
+	testCases := []struct {
+		limitInWatts  string
+		expectedLimit *int
+	}{
+		{
+			limitInWatts:  "222",
+			expectedLimit: ptr.To(222),
+		},
+		{
+			limitInWatts:  "0",
+			expectedLimit: nil,
+		},
+		{
+			limitInWatts:  "null",
+			expectedLimit: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			overrides: map[string]http.HandlerFunc{
+				"/redfish/v1/Chassis/System.Embedded.1/Power": func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(strings.Replace(redfishPowerJSONResponse,
+						`"LimitInWatts": 222`, `"LimitInWatts": `+testCase.limitInWatts, 1)))
+				},
+			},
+		})
+
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		limit, err := bmc.GetPowerLimit()
+
+		redfishServer.Close()
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedLimit, limit)
+	}
+}
+
+func TestBMCSetPowerLimit(t *testing.T) {
+	testCases := []struct {
+		watts             int
+		powerControlIndex int
+		expectedError     string
+	}{
+		{
+			watts:             300,
+			powerControlIndex: 0,
+		},
+		{
+			watts:             0,
+			powerControlIndex: 0,
+		},
+		{
+			watts:             -1,
+			powerControlIndex: 0,
+			expectedError:     "power limit 'watts' cannot be negative",
+		},
+		{
+			watts:             300,
+			powerControlIndex: 1,
+			expectedError: "failed to get redfish power control: invalid power control index 1 " +
+				"(base-index=0, num power control=1)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var patchBody *struct {
+			PowerControl []struct {
+				PowerLimit struct {
+					LimitInWatts *int
+				}
+			}
+		}
+
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			overrides: map[string]http.HandlerFunc{
+				"/redfish/v1/Chassis/System.Embedded.1/Power": func(w http.ResponseWriter, r *http.Request) {
+					if r.Method == http.MethodPatch {
+						_ = json.NewDecoder(r.Body).Decode(&patchBody)
+
+						w.WriteHeader(http.StatusNoContent)
+
+						return
+					}
+
+					_, _ = w.Write([]byte(redfishPowerJSONResponse))
+				},
+			},
+		})
+
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword).
+			WithRedfishPowerControlIndex(testCase.powerControlIndex)
+
+		err := bmc.SetPowerLimit(testCase.watts)
+
+		redfishServer.Close()
+
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+			assert.Nil(t, patchBody)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+
+		if assert.NotNil(t, patchBody) && assert.Len(t, patchBody.PowerControl, 1) {
+			// A zero limit removes the power cap, so it is sent as null.
+			expectedLimit := ptr.To(testCase.watts)
+			if testCase.watts == 0 {
+				expectedLimit = nil
+			}
+
+			assert.Equal(t, expectedLimit, patchBody.PowerControl[0].PowerLimit.LimitInWatts)
+		}
+	}
+
+	// Check that a limit read back after setting it matches, including removing the cap.
+	limitInWatts := "222"
+
+	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/Chassis/System.Embedded.1/Power": func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPatch {
+					var body struct {
+						PowerControl []struct {
+							PowerLimit struct {
+								LimitInWatts json.RawMessage
+							}
+						}
+					}
+
+					_ = json.NewDecoder(r.Body).Decode(&body)
+					limitInWatts = string(body.PowerControl[0].PowerLimit.LimitInWatts)
+
+					w.WriteHeader(http.StatusNoContent)
+
+					return
+				}
+
+				_, _ = w.Write([]byte(strings.Replace(redfishPowerJSONResponse,
+					`"LimitInWatts": 222`, `"LimitInWatts": `+limitInWatts, 1)))
+			},
+		},
+	})
+	defer redfishServer.Close()
+
+	host := strings.Split(redfishServer.URL, "//")[1]
+	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+	for _, watts := range []int{300, 0} {
+		assert.NoError(t, bmc.SetPowerLimit(watts))
+
+		expectedLimit := ptr.To(watts)
+		if watts == 0 {
+			expectedLimit = nil
+		}
+
+		limit, err := bmc.GetPowerLimit()
+		assert.NoError(t, err)
+		assert.Equal(t, expectedLimit, limit)
+	}
+}
+
+func TestBMCRedfishServiceInfo(t *testing.T) {
+	testCases := []struct {
+		name            string
+		rootResponse    string
+		expectedVersion string
+		expectedVendor  string
+	}{
+		{
+			name:            "dell vendor",
+			rootResponse:    redfishRootJSONResponse,
+			expectedVersion: "1.17.0",
+			expectedVendor:  "Dell",
+		},
+		{
+			name:            "hpe vendor",
+			rootResponse:    strings.Replace(redfishRootJSONResponse, `"Vendor": "Dell"`, `"Vendor": "HPE"`, 1),
+			expectedVersion: "1.17.0",
+			expectedVendor:  "HPE",
+		},
+		{
+			name:            "vendor from oem",
+			rootResponse:    strings.Replace(redfishRootJSONResponse, `"Vendor": "Dell"`, `"Vendor": ""`, 1),
+			expectedVersion: "1.17.0",
+			expectedVendor:  "Dell",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+				overrides: map[string]http.HandlerFunc{
+					"/redfish/v1/": func(w http.ResponseWriter, r *http.Request) {
+						_, _ = w.Write([]byte(testCase.rootResponse))
+					},
+				},
+			})
+			defer redfishServer.Close()
+
+			host := strings.Split(redfishServer.URL, "//")[1]
+			bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+			version, vendor, err := bmc.RedfishServiceInfo()
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedVersion, version)
+			assert.Equal(t, testCase.expectedVendor, vendor)
+		})
+	}
+}
+
+func TestBMCInsertVirtualMedia(t *testing.T) {
+	const testImage = "http://example.com/test.iso"
+
+	testCases := []struct {
+		image         string
+		mediaType     redfish.VirtualMediaType
+		expectedSlot  string
+		expectedError string
+	}{
+		{
+			image:        testImage,
+			mediaType:    "",
+			expectedSlot: "CD",
+		},
+		{
+			image:        testImage,
+			mediaType:    redfish.DVDMediaType,
+			expectedSlot: "CD",
+		},
+		{
+			image:        testImage,
+			mediaType:    redfish.USBStickMediaType,
+			expectedSlot: "RemovableDisk",
+		},
+		{
+			image:     testImage,
+			mediaType: redfish.FloppyMediaType,
+			expectedError: "failed to get redfish virtual media: failed to get virtual media: " +
+				"no slot supporting media types [Floppy] found",
+		},
+		{
+			image:         "",
+			mediaType:     "",
+			expectedError: "virtual media 'image' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var (
+			requestPath  string
+			requestImage string
+		)
+
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			virtualMedia: func(r *http.Request) {
+				requestPath = r.URL.Path
+
+				var body struct{ Image string }
+
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				requestImage = body.Image
+			},
+		})
+
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+
+		err := bmc.InsertVirtualMedia(testCase.image, testCase.mediaType)
+
+		redfishServer.Close()
+
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+			assert.Empty(t, requestPath)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(
+			"/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/%s/Actions/VirtualMedia.InsertMedia",
+			testCase.expectedSlot), requestPath)
+		assert.Equal(t, testCase.image, requestImage)
+	}
+}
+
+func TestBMCInsertVirtualMediaFromShare(t *testing.T) {
+	testCases := []struct {
+		shareURL         string
+		username         string
+		password         string
+		mediaType        redfish.VirtualMediaType
+		expectedProtocol string
+		expectedError    string
+	}{
+		{
+			shareURL:         "nfs://192.168.1.1/exports/test.iso",
+			expectedProtocol: "NFS",
+		},
+		{
+			shareURL:         "cifs://192.168.1.1/share/test.iso",
+			username:         "shareuser",
+			password:         "sharepassword",
+			expectedProtocol: "CIFS",
+		},
+		{
+			shareURL:         "SMB://192.168.1.1/share/test.img",
+			username:         "shareuser",
+			password:         "sharepassword",
+			mediaType:        redfish.USBStickMediaType,
+			expectedProtocol: "CIFS",
+		},
+		{
+			shareURL:      "http://example.com/test.iso",
+			expectedError: `virtual media share URL scheme "http" is not one of nfs, cifs, or smb`,
+		},
+		{
+			shareURL:      "cifs://192.168.1.1/share/test.iso",
+			username:      "shareuser",
+			expectedError: "virtual media share 'password' cannot be empty when 'username' is set",
+		},
+		{
+			shareURL:      "",
+			expectedError: "virtual media 'shareURL' cannot be empty",
+		},
+	}
 
-func TestBMCSystemResetAction(t *testing.T) {
-	resetActions := []redfish.ResetType{
-		redfish.OnResetType,
-		redfish.ForceOnResetType,
-		redfish.ForceOffResetType,
-		redfish.ForceRestartResetType,
-		redfish.GracefulRestartResetType,
-		redfish.GracefulShutdownResetType,
-		redfish.PushPowerButtonResetType,
-		redfish.NmiResetType,
-		redfish.PauseResetType,
-		redfish.ResumeResetType,
-		redfish.SuspendResetType,
-	}
+	for _, testCase := range testCases {
+		var requestConfig *redfish.VirtualMediaConfig
 
-	for _, resetAction := range resetActions {
-		testResetAction(t, string(resetAction), func(bmc *BMC) error {
-			return bmc.SystemResetAction(resetAction)
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			virtualMedia: func(r *http.Request) {
+				requestConfig = &redfish.VirtualMediaConfig{}
+
+				_ = json.NewDecoder(r.Body).Decode(requestConfig)
+			},
 		})
-	}
-}
 
-func TestBMCSystemForceReset(t *testing.T) {
-	testResetAction(t, "ForceReset", func(bmc *BMC) error {
-		return bmc.SystemForceReset()
-	})
-}
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
 
-func TestBMCSystemGracefulShutdown(t *testing.T) {
-	testResetAction(t, "GracefulShutdown", func(bmc *BMC) error {
-		return bmc.SystemGracefulShutdown()
-	})
-}
+		err := bmc.InsertVirtualMediaFromShare(
+			testCase.shareURL, testCase.username, testCase.password, testCase.mediaType)
 
-func TestBMCSystemPowerOn(t *testing.T) {
-	testResetAction(t, "PowerOn", func(bmc *BMC) error {
-		return bmc.SystemPowerOn()
-	})
-}
+		redfishServer.Close()
 
-func TestBMCSystemPowerOff(t *testing.T) {
-	testResetAction(t, "PowerOff", func(bmc *BMC) error {
-		return bmc.SystemPowerOff()
-	})
-}
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+			assert.Nil(t, requestConfig)
 
-func TestBMCSystemPowerCycle(t *testing.T) {
-	testResetAction(t, "PowerCycle", func(bmc *BMC) error {
-		return bmc.SystemPowerCycle()
-	})
+			continue
+		}
+
+		assert.NoError(t, err)
+
+		if assert.NotNil(t, requestConfig) {
+			assert.Equal(t, testCase.shareURL, requestConfig.Image)
+			assert.Equal(t, testCase.expectedProtocol, requestConfig.TransferProtocolType)
+			assert.Equal(t, testCase.username, requestConfig.UserName)
+			assert.Equal(t, testCase.password, requestConfig.Password)
+			assert.True(t, requestConfig.Inserted)
+		}
+	}
 }
 
-func TestBMCSystemPowerState(t *testing.T) {
-	// Create fake redfish endpoint.
-	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
-	defer redfishServer.Close()
+func TestBMCEjectVirtualMedia(t *testing.T) {
+	testCases := []struct {
+		inserted              bool
+		removableDiskInserted bool
+		expectedPaths         []string
+	}{
+		{
+			inserted:      false,
+			expectedPaths: nil,
+		},
+		{
+			inserted: true,
+			expectedPaths: []string{
+				"/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/CD/Actions/VirtualMedia.EjectMedia",
+			},
+		},
+		{
+			// Media in slots not used by InsertVirtualMedia is left inserted.
+			inserted:              false,
+			removableDiskInserted: true,
+			expectedPaths:         nil,
+		},
+	}
 
-	host := strings.Split(redfishServer.URL, "//")[1]
-	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+	for _, testCase := range testCases {
+		var requestPaths []string
 
-	const expectedPowerState = "On"
+		cdResponse := redfishVirtualMediaCDJSONResponse
+		if testCase.inserted {
+			cdResponse = strings.Replace(cdResponse, `"Inserted": false`, `"Inserted": true`, 1)
+		}
 
-	powerState, err := bmc.SystemPowerState()
-	assert.NoError(t, err)
-	assert.Equal(t, expectedPowerState, powerState)
-}
+		removableDiskResponse := redfishVirtualMediaRemovableDiskJSONResponse
+		if testCase.removableDiskInserted {
+			removableDiskResponse = strings.Replace(removableDiskResponse, `"Inserted": false`, `"Inserted": true`, 1)
+		}
 
-func TestBMCPowerUsage(t *testing.T) {
-	// Create a fake redfish api endpoint with secureBoot "disabled"
-	redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{})
-	defer redfishServer.Close()
+		redfishServer := createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+			virtualMedia: func(r *http.Request) {
+				requestPaths = append(requestPaths, r.URL.Path)
+			},
+			overrides: map[string]http.HandlerFunc{
+				"/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/CD": func(w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(cdResponse))
+				},
+				"/redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/RemovableDisk": func(
+					w http.ResponseWriter, r *http.Request) {
+					_, _ = w.Write([]byte(removableDiskResponse))
+				},
+			},
+		})
 
-	host := strings.Split(redfishServer.URL, "//")[1]
-	bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
+		host := strings.Split(redfishServer.URL, "//")[1]
+		bmc := New(host).WithRedfishUser(defaultUsername, defaultPassword)
 
-	const expectedPowerUsage float32 = 360.0
+		err := bmc.EjectVirtualMedia()
 
-	power, err := bmc.PowerUsage()
-	assert.NoError(t, err)
-	assert.Equal(t, expectedPowerUsage, power)
+		redfishServer.Close()
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedPaths, requestPaths)
+	}
+
+	// Check that the Redfish user is required.
+	err := New("1.2.3.4").EjectVirtualMedia()
+	assert.EqualError(t, err, "cannot access redfish with nil user")
 }
 
 func TestBMCCreateCLISSHSession(t *testing.T) {
@@ -627,6 +3047,73 @@ func TestBMCRunCLICommand(t *testing.T) {
 
 	_, _, err := bmc.RunCLICommand("help", false, 5*time.Second)
 	assert.EqualError(t, err, expectedErrMsg)
+
+	// Check that the combined output of a failed command is not returned.
+	host, port := createFakeSSHServer(t)
+	bmc = New(host).WithSSHUser(defaultUsername, defaultPassword).WithSSHPort(port)
+
+	stdout, stderr, err := bmc.RunCLICommand("exit 1", true, 5*time.Second)
+	assert.ErrorContains(t, err, "command run error")
+	assert.Empty(t, stdout)
+	assert.Empty(t, stderr)
+}
+
+func TestBMCRunCLICommandWithExitCode(t *testing.T) {
+	host, port := createFakeSSHServer(t)
+
+	testCases := []struct {
+		cmd              string
+		combineOutput    bool
+		expectedStdout   string
+		expectedStderr   string
+		expectedExitCode int
+	}{
+		{
+			cmd:              "help",
+			combineOutput:    false,
+			expectedStdout:   "help",
+			expectedStderr:   "error",
+			expectedExitCode: 0,
+		},
+		{
+			cmd:              "exit 3",
+			combineOutput:    false,
+			expectedStdout:   "exit 3",
+			expectedStderr:   "error",
+			expectedExitCode: 3,
+		},
+		{
+			cmd:              "exit 1",
+			combineOutput:    true,
+			expectedStdout:   "exit 1",
+			expectedStderr:   "",
+			expectedExitCode: 1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		bmc := New(host).WithSSHUser(defaultUsername, defaultPassword).WithSSHPort(port)
+
+		stdout, stderr, exitCode, err := bmc.RunCLICommandWithExitCode(testCase.cmd, testCase.combineOutput, 5*time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedStderr, stderr)
+		assert.Equal(t, testCase.expectedExitCode, exitCode)
+
+		// The order in which stdout and stderr are combined is not deterministic.
+		if testCase.combineOutput {
+			assert.Contains(t, stdout, testCase.expectedStdout)
+			assert.Contains(t, stdout, "error")
+		} else {
+			assert.Equal(t, testCase.expectedStdout, stdout)
+		}
+	}
+
+	// A failure to run the command is still reported as an error.
+	bmc := New(host).WithSSHUser(defaultUsername, "wrong-password").WithSSHPort(port)
+
+	_, _, exitCode, err := bmc.RunCLICommandWithExitCode("help", false, 5*time.Second)
+	assert.ErrorContains(t, err, "failed to connect to CLI")
+	assert.Equal(t, -1, exitCode)
 }
 
 func TestBMCSerialConsole(t *testing.T) {
@@ -654,6 +3141,114 @@ func TestBMCSerialConsole(t *testing.T) {
 	assert.EqualError(t, err, expectedErrMsg)
 }
 
+// createFakeSSHServer starts an SSH server on localhost that accepts the default credentials and session channels,
+// returning its host and port. The server is stopped when the test finishes.
+func createFakeSSHServer(t *testing.T) (string, uint16) {
+	t.Helper()
+
+	hostKey, err := ssh.ParsePrivateKey(generateSSHPrivateKey(t, ""))
+	assert.NoError(t, err)
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == defaultUsername && string(password) == defaultPassword {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("invalid credentials")
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go serveFakeSSHConn(conn, config)
+		}
+	}()
+
+	tcpAddr, ok := listener.Addr().(*net.TCPAddr)
+	assert.True(t, ok)
+
+	return tcpAddr.IP.String(), uint16(tcpAddr.Port)
+}
+
+// serveFakeSSHConn performs the server side of the SSH handshake and accepts all session channels, running any exec
+// requests on them with serveFakeSSHExec.
+func serveFakeSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+
+		go serveFakeSSHExec(channel, channelRequests)
+
+		defer channel.Close()
+	}
+}
+
+// serveFakeSSHExec replies to the exec requests of a session channel by writing the command to stdout and "error" to
+// stderr. Commands of the form "exit <code>" exit with that code, while all others exit with 0.
+func serveFakeSSHExec(channel ssh.Channel, requests <-chan *ssh.Request) {
+	for request := range requests {
+		if request.Type != "exec" {
+			_ = request.Reply(false, nil)
+
+			continue
+		}
+
+		var payload struct{ Command string }
+
+		_ = ssh.Unmarshal(request.Payload, &payload)
+		_ = request.Reply(true, nil)
+
+		var exitCode uint32
+
+		_, _ = fmt.Sscanf(payload.Command, "exit %d", &exitCode)
+		_, _ = channel.Write([]byte(payload.Command))
+		_, _ = channel.Stderr().Write([]byte("error"))
+		_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{Status: exitCode}))
+		_ = channel.Close()
+	}
+}
+
+// generateSSHPrivateKey returns a new PEM encoded ed25519 private key, encrypted if passphrase is not empty.
+func generateSSHPrivateKey(t *testing.T, passphrase string) []byte {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	var block *pem.Block
+
+	if passphrase == "" {
+		block, err = ssh.MarshalPrivateKey(key, "")
+	} else {
+		block, err = ssh.MarshalPrivateKeyWithPassphrase(key, "", []byte(passphrase))
+	}
+
+	assert.NoError(t, err)
+
+	return pem.EncodeToMemory(block)
+}
+
 func getDelayResponseCallbackFn(t *testing.T, respDelay time.Duration) func(r *http.Request) {
 	t.Helper()
 
@@ -687,6 +3282,32 @@ func getAuthDataCallbackFn(t *testing.T, user *User) func(r *http.Request) {
 // it will be filled with the auth credentials received in the login request. All the responses, except the login one,
 // are sent using static json data from the testdata folder. The flag secureBootEnable is used to load the json response
 // for the secure boot api depending on wether we want it to be enabled or disabled for our test.
+// createFakeRedfishNoSELServer creates a fake redfish server where the manager only has a non-SEL log service.
+func createFakeRedfishNoSELServer() *httptest.Server {
+	return createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/Managers/iDRAC.Embedded.1/LogServices": func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(strings.Replace(redfishLogServicesJSONResponse, `,
+        {
+            "@odata.id": "/redfish/v1/Managers/iDRAC.Embedded.1/LogServices/Sel"
+        }`, "", 1)))
+			},
+		},
+	})
+}
+
+// createFakeRedfishThermalLessServer creates a fake redfish server where no chassis has a thermal link.
+func createFakeRedfishThermalLessServer() *httptest.Server {
+	return createFakeRedfishLocalServer(false, redfishAPIResponseCallbacks{
+		overrides: map[string]http.HandlerFunc{
+			"/redfish/v1/Chassis/System.Embedded.1": func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(strings.Replace(redfishChassisJSONResponse,
+					`"Thermal": {`, `"ThermalRemoved": {`, 1)))
+			},
+		},
+	})
+}
+
 func createFakeRedfishLocalServer(secureBootEnabled bool, callbacks redfishAPIResponseCallbacks) *httptest.Server {
 	sbEnabled := secureBootEnabled
 	mux := http.NewServeMux()
@@ -735,6 +3356,49 @@ func createFakeRedfishLocalServer(secureBootEnabled bool, callbacks redfishAPIRe
 			}
 		}))
 
+	registerFakeRedfishStorageHandlers(mux)
+	registerFakeRedfishChassisHandlers(mux, callbacks)
+	registerFakeRedfishServiceHandlers(mux, callbacks)
+	registerFakeRedfishManagerHandlers(mux, callbacks)
+
+	redfishServer := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if override, found := callbacks.overrides[r.URL.Path]; found {
+			override(w, r)
+
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	}))
+	redfishServer.EnableHTTP2 = true
+	redfishServer.StartTLS()
+
+	return redfishServer
+}
+
+// registerFakeRedfishStorageHandlers registers the storage controller and drive endpoints of the fake redfish server.
+func registerFakeRedfishStorageHandlers(mux *http.ServeMux) {
+	storagePath := "/redfish/v1/Systems/System.Embedded.1/Storage"
+	raidDrivesPath := storagePath + "/RAID.Integrated.1-1/Drives"
+	ahciDrivesPath := storagePath + "/AHCI.Embedded.1-1/Drives"
+	storageResponses := map[string]string{
+		storagePath:                          redfishStorageCollectionJSONResponse,
+		storagePath + "/RAID.Integrated.1-1": redfishStorageRAIDJSONResponse,
+		storagePath + "/AHCI.Embedded.1-1":   redfishStorageAHCIJSONResponse,
+		raidDrivesPath + "/Disk.Bay.0:Enclosure.Internal.0-1:RAID.Integrated.1-1": redfishStorageRAIDDrive0JSONResponse,
+		raidDrivesPath + "/Disk.Bay.1:Enclosure.Internal.0-1:RAID.Integrated.1-1": redfishStorageRAIDDrive1JSONResponse,
+		ahciDrivesPath + "/Disk.Direct.0-0:AHCI.Embedded.1-1":                     redfishStorageAHCIDrive0JSONResponse,
+	}
+
+	for path, response := range storageResponses {
+		mux.HandleFunc("GET "+path, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(response))
+		}))
+	}
+}
+
+// registerFakeRedfishChassisHandlers registers the chassis, power, and thermal endpoints of the fake redfish server.
+func registerFakeRedfishChassisHandlers(mux *http.ServeMux, callbacks redfishAPIResponseCallbacks) {
 	mux.HandleFunc("GET /redfish/v1/Chassis", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if callbacks.chassis != nil {
 			callbacks.chassis(r)
@@ -748,6 +3412,15 @@ func createFakeRedfishLocalServer(secureBootEnabled bool, callbacks redfishAPIRe
 			_, _ = w.Write([]byte(redfishChassisJSONResponse))
 		}))
 
+	mux.HandleFunc("PATCH /redfish/v1/Chassis/System.Embedded.1",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if callbacks.chassisLED != nil {
+				callbacks.chassisLED(r)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
 	mux.HandleFunc("GET /redfish/v1/Chassis/Enclosure.Internal.0-1",
 		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			_, _ = w.Write([]byte(redfishChassisNoPowerJSONResponse))
@@ -762,11 +3435,123 @@ func createFakeRedfishLocalServer(secureBootEnabled bool, callbacks redfishAPIRe
 			_, _ = w.Write([]byte(redfishPowerJSONResponse))
 		}))
 
-	redfishServer := httptest.NewUnstartedServer(mux)
-	redfishServer.EnableHTTP2 = true
-	redfishServer.StartTLS()
+	mux.HandleFunc("GET /redfish/v1/Chassis/System.Embedded.1/Thermal",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishThermalJSONResponse))
+		}))
+}
 
-	return redfishServer
+// registerFakeRedfishServiceHandlers registers the AccountService and UpdateService endpoints of the fake redfish
+// server.
+func registerFakeRedfishServiceHandlers(mux *http.ServeMux, callbacks redfishAPIResponseCallbacks) {
+	mux.HandleFunc("GET /redfish/v1/AccountService", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(redfishAccountServiceJSONResponse))
+	}))
+
+	mux.HandleFunc("GET /redfish/v1/AccountService/Accounts",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishAccountsJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/AccountService/Accounts/2",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishAccount2JSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/AccountService/Accounts/3",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishAccount3JSONResponse))
+		}))
+
+	mux.HandleFunc("PATCH /redfish/v1/AccountService/Accounts/{id}",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if callbacks.account != nil {
+				callbacks.account(r)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/UpdateService", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(redfishUpdateServiceJSONResponse))
+	}))
+
+	mux.HandleFunc("GET /redfish/v1/UpdateService/FirmwareInventory",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishFirmwareInventoryJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/UpdateService/FirmwareInventory/Installed-159-1.13.2",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishFirmwareInventoryBIOSJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/UpdateService/FirmwareInventory/Installed-25227-7.00.00.171",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishFirmwareInventoryIDRACJSONResponse))
+		}))
+}
+
+// registerFakeRedfishManagerHandlers registers the manager, log service, and virtual media endpoints of the fake
+// redfish server.
+func registerFakeRedfishManagerHandlers(mux *http.ServeMux, callbacks redfishAPIResponseCallbacks) {
+	mux.HandleFunc("GET /redfish/v1/Managers", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(redfishManagersJSONResponse))
+	}))
+
+	mux.HandleFunc("GET /redfish/v1/Managers/iDRAC.Embedded.1",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishManagerJSONResponse))
+		}))
+
+	logServicesPath := "/redfish/v1/Managers/iDRAC.Embedded.1/LogServices"
+	logServicesResponses := map[string]string{
+		logServicesPath:                    redfishLogServicesJSONResponse,
+		logServicesPath + "/Lclog":         redfishLogServiceLclogJSONResponse,
+		logServicesPath + "/Sel":           redfishLogServiceSELJSONResponse,
+		logServicesPath + "/Sel/Entries":   redfishSELEntriesJSONResponse,
+		logServicesPath + "/Sel/Entries/1": redfishSELEntry1JSONResponse,
+		logServicesPath + "/Sel/Entries/2": redfishSELEntry2JSONResponse,
+	}
+
+	for path, response := range logServicesResponses {
+		mux.HandleFunc("GET "+path, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(response))
+		}))
+	}
+
+	mux.HandleFunc("POST "+logServicesPath+"/Sel/Actions/LogService.ClearLog",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if callbacks.clearLog != nil {
+				callbacks.clearLog(r)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishVirtualMediaCollectionJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/CD",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishVirtualMediaCDJSONResponse))
+		}))
+
+	mux.HandleFunc("GET /redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/RemovableDisk",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(redfishVirtualMediaRemovableDiskJSONResponse))
+		}))
+
+	mux.HandleFunc("POST /redfish/v1/Managers/iDRAC.Embedded.1/VirtualMedia/{slot}/Actions/{action}",
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if callbacks.virtualMedia != nil {
+				callbacks.virtualMedia(r)
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
 }
 
 // testResetAction performs unit testing for a provided function that performs a reset action on the BMC.
@@ -2,6 +2,8 @@ package bmh
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"time"
 
 	goclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -19,6 +21,16 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// knownBMCProtocols lists the BMC address schemes supported by metal3. Apart from those in
+// noTransportBMCProtocols, each may also be suffixed with +http or +https.
+var knownBMCProtocols = []string{
+	"ipmi", "libvirt", "irmc", "ibmc", "idrac", "idrac-redfish", "idrac-virtualmedia", "ilo4", "ilo4-virtualmedia",
+	"ilo5", "ilo5-redfish", "ilo5-virtualmedia", "redfish", "redfish-virtualmedia",
+}
+
+// noTransportBMCProtocols lists the BMC address schemes that cannot be suffixed with +http or +https.
+var noTransportBMCProtocols = []string{"ipmi", "libvirt", "irmc"}
+
 // BmhBuilder provides struct for the bmh object containing connection to
 // the cluster and the bmh definitions.
 type BmhBuilder struct {
@@ -332,6 +344,128 @@ func (builder *BmhBuilder) WithRootDeviceRotationalDisk(rotational bool) *BmhBui
 	return builder
 }
 
+// WithBMCProtocol sets the scheme of the BMC address to the specified metal3 protocol, such as redfish-virtualmedia,
+// idrac-virtualmedia, or ipmi, replacing any scheme already present in the address.
+func (builder *BmhBuilder) WithBMCProtocol(protocol string) *BmhBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting bmh %s in namespace %s BMC protocol to %s",
+		builder.Definition.Name, builder.Definition.Namespace, protocol)
+
+	if protocol == "" {
+		glog.V(100).Infof("The baremetalhost BMC protocol is empty")
+
+		builder.errorMsg = "the baremetalhost BMC protocol cannot be empty"
+
+		return builder
+	}
+
+	baseProtocol, transport, hasTransport := strings.Cut(protocol, "+")
+	if !slices.Contains(knownBMCProtocols, baseProtocol) ||
+		(hasTransport && transport != "http" && transport != "https") {
+		glog.V(100).Infof("The baremetalhost BMC protocol %s is unknown", protocol)
+
+		builder.errorMsg = fmt.Sprintf("the baremetalhost BMC protocol %s is not one of %v", protocol, knownBMCProtocols)
+
+		return builder
+	}
+
+	if hasTransport && slices.Contains(noTransportBMCProtocols, baseProtocol) {
+		glog.V(100).Infof("The baremetalhost BMC protocol %s does not support a transport suffix", baseProtocol)
+
+		builder.errorMsg = fmt.Sprintf(
+			"the baremetalhost BMC protocol %s does not support the +%s suffix", baseProtocol, transport)
+
+		return builder
+	}
+
+	address := builder.Definition.Spec.BMC.Address
+	if _, hostAndPath, found := strings.Cut(address, "://"); found {
+		address = hostAndPath
+	}
+
+	if address == "" {
+		glog.V(100).Infof("The baremetalhost BMC address is empty")
+
+		builder.errorMsg = "the baremetalhost BMC address cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.BMC.Address = fmt.Sprintf("%s://%s", protocol, address)
+
+	return builder
+}
+
+// WithInspectAnnotation sets the inspect annotation to disabled so that the baremetal-operator skips inspection of the
+// bmh during registration. If disabled is false, the annotation is removed and inspection is performed.
+func (builder *BmhBuilder) WithInspectAnnotation(disabled bool) *BmhBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting bmh %s in namespace %s inspection disabled to %t",
+		builder.Definition.Name, builder.Definition.Namespace, disabled)
+
+	if !disabled {
+		delete(builder.Definition.Annotations, bmhv1alpha1.InspectAnnotationPrefix)
+
+		return builder
+	}
+
+	if builder.Definition.Annotations == nil {
+		builder.Definition.Annotations = make(map[string]string)
+	}
+
+	builder.Definition.Annotations[bmhv1alpha1.InspectAnnotationPrefix] = bmhv1alpha1.InspectAnnotationValueDisabled
+
+	return builder
+}
+
+// WithHardwareDetailsAnnotation sets the hardware details annotation to the JSON encoding of hardwareDetails. The
+// baremetal-operator only uses this annotation when inspection is disabled, see WithInspectAnnotation.
+func (builder *BmhBuilder) WithHardwareDetailsAnnotation(hardwareDetails *bmhv1alpha1.HardwareDetails) *BmhBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting bmh %s in namespace %s hardware details annotation",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if hardwareDetails == nil {
+		glog.V(100).Infof("The baremetalhost hardware details are nil")
+
+		builder.errorMsg = "the baremetalhost hardware details cannot be nil"
+
+		return builder
+	}
+
+	err := builder.setJSONAnnotation(bmhv1alpha1.HardwareDetailsAnnotation, hardwareDetails)
+	if err != nil {
+		glog.V(100).Infof("Failed to set hardware details annotation: %v", err)
+
+		builder.errorMsg = err.Error()
+	}
+
+	return builder
+}
+
+// SetStatusAnnotation sets the status annotation of the bmh definition to the JSON encoding of status. The
+// baremetal-operator restores the status of a bmh from this annotation when its status is empty, such as after it is
+// moved to another cluster. It should be called before Create.
+func (builder *BmhBuilder) SetStatusAnnotation(status bmhv1alpha1.BareMetalHostStatus) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Setting bmh %s in namespace %s status annotation",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return builder.setJSONAnnotation(bmhv1alpha1.StatusAnnotation, status)
+}
+
 // WithOptions creates bmh with generic mutation options.
 func (builder *BmhBuilder) WithOptions(options ...AdditionalOptions) *BmhBuilder {
 	if valid, _ := builder.validate(); !valid {
@@ -511,6 +645,108 @@ func (builder *BmhBuilder) GetBmhPowerOnStatus() bool {
 	return builder.Object.Status.PoweredOn
 }
 
+// GetStatusAnnotation returns the status stored in the status annotation of the bmh on the cluster.
+func (builder *BmhBuilder) GetStatusAnnotation() (*bmhv1alpha1.BareMetalHostStatus, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting status annotation of bmh %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	status := &bmhv1alpha1.BareMetalHostStatus{}
+
+	err := builder.getJSONAnnotation(bmhv1alpha1.StatusAnnotation, status)
+	if err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// GetHardwareDetailsAnnotation returns the hardware details stored in the hardware details annotation of the bmh on
+// the cluster.
+func (builder *BmhBuilder) GetHardwareDetailsAnnotation() (*bmhv1alpha1.HardwareDetails, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting hardware details annotation of bmh %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	hardwareDetails := &bmhv1alpha1.HardwareDetails{}
+
+	err := builder.getJSONAnnotation(bmhv1alpha1.HardwareDetailsAnnotation, hardwareDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	return hardwareDetails, nil
+}
+
+// Detach sets the detached annotation on the bmh, temporarily removing it from management by the
+// baremetal-operator.
+func (builder *BmhBuilder) Detach() (*BmhBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Detaching baremetalhost %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("bmh object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	if builder.Object.Annotations == nil {
+		builder.Object.Annotations = make(map[string]string)
+	}
+
+	builder.Object.Annotations[bmhv1alpha1.DetachedAnnotation] = ""
+
+	err := builder.apiClient.Update(context.TODO(), builder.Object)
+	if err != nil {
+		glog.V(100).Infof("Failed to set detached annotation on bmh %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return builder, fmt.Errorf("failed to detach bmh: %w", err)
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// Attach removes the detached annotation from the bmh, returning it to management by the baremetal-operator.
+func (builder *BmhBuilder) Attach() (*BmhBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Attaching baremetalhost %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("bmh object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	delete(builder.Object.Annotations, bmhv1alpha1.DetachedAnnotation)
+
+	err := builder.apiClient.Update(context.TODO(), builder.Object)
+	if err != nil {
+		glog.V(100).Infof("Failed to remove detached annotation from bmh %s in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return builder, fmt.Errorf("failed to attach bmh: %w", err)
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
 // CreateAndWaitUntilProvisioned creates bmh object and waits until bmh is provisioned.
 func (builder *BmhBuilder) CreateAndWaitUntilProvisioned(timeout time.Duration) (*BmhBuilder, error) {
 	if valid, err := builder.validate(); !valid {
@@ -660,3 +896,43 @@ func (builder *BmhBuilder) validate() (bool, error) {
 
 	return true, nil
 }
+
+// setJSONAnnotation sets the annotation key of the bmh definition to the JSON encoding of value.
+func (builder *BmhBuilder) setJSONAnnotation(key string, value any) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal bmh annotation %s: %w", key, err)
+	}
+
+	if builder.Definition.Annotations == nil {
+		builder.Definition.Annotations = make(map[string]string)
+	}
+
+	builder.Definition.Annotations[key] = string(encoded)
+
+	return nil
+}
+
+// getJSONAnnotation decodes the annotation key of the bmh on the cluster into value.
+func (builder *BmhBuilder) getJSONAnnotation(key string, value any) error {
+	if !builder.Exists() {
+		return fmt.Errorf("bmh object %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	encoded, found := builder.Object.Annotations[key]
+	if !found {
+		return fmt.Errorf("bmh object %s in namespace %s does not have annotation %s",
+			builder.Definition.Name, builder.Definition.Namespace, key)
+	}
+
+	err := json.Unmarshal([]byte(encoded), value)
+	if err != nil {
+		glog.V(100).Infof("Failed to unmarshal annotation %s of bmh %s in namespace %s: %v",
+			key, builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return fmt.Errorf("failed to unmarshal bmh annotation %s: %w", key, err)
+	}
+
+	return nil
+}
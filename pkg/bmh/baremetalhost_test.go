@@ -667,6 +667,159 @@ func TestBareMetalHostWithRootDeviceRotationalDisk(t *testing.T) {
 	}
 }
 
+func TestBareMetalHostWithBMCProtocol(t *testing.T) {
+	testCases := []struct {
+		address         string
+		protocol        string
+		expectedAddress string
+		expectedError   string
+	}{
+		{
+			address:         defaultBmHostAddress,
+			protocol:        "ipmi",
+			expectedAddress: "ipmi://1.1.1.1",
+		},
+		{
+			address:         "redfish://1.1.1.1/redfish/v1/Systems/1",
+			protocol:        "redfish-virtualmedia",
+			expectedAddress: "redfish-virtualmedia://1.1.1.1/redfish/v1/Systems/1",
+		},
+		{
+			address:         "1.1.1.1:8443/redfish/v1/Systems/System.Embedded.1",
+			protocol:        "idrac-virtualmedia+https",
+			expectedAddress: "idrac-virtualmedia+https://1.1.1.1:8443/redfish/v1/Systems/System.Embedded.1",
+		},
+		{
+			address:  defaultBmHostAddress,
+			protocol: "foo-virtualmedia",
+			expectedError: "the baremetalhost BMC protocol foo-virtualmedia is not one of [ipmi libvirt irmc ibmc idrac " +
+				"idrac-redfish idrac-virtualmedia ilo4 ilo4-virtualmedia ilo5 ilo5-redfish ilo5-virtualmedia redfish " +
+				"redfish-virtualmedia]",
+		},
+		{
+			address:  defaultBmHostAddress,
+			protocol: "redfish+ftp",
+			expectedError: "the baremetalhost BMC protocol redfish+ftp is not one of [ipmi libvirt irmc ibmc idrac " +
+				"idrac-redfish idrac-virtualmedia ilo4 ilo4-virtualmedia ilo5 ilo5-redfish ilo5-virtualmedia redfish " +
+				"redfish-virtualmedia]",
+		},
+		{
+			address:       defaultBmHostAddress,
+			protocol:      "ipmi+https",
+			expectedError: "the baremetalhost BMC protocol ipmi does not support the +https suffix",
+		},
+		{
+			address:       defaultBmHostAddress,
+			protocol:      "",
+			expectedError: "the baremetalhost BMC protocol cannot be empty",
+		},
+		{
+			address:       "",
+			protocol:      "redfish",
+			expectedError: "the baremetalhost BMC address cannot be empty",
+		},
+		{
+			address:       "ipmi://",
+			protocol:      "redfish",
+			expectedError: "the baremetalhost BMC address cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBmHostBuilder := buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject())
+		testBmHostBuilder.Definition.Spec.BMC.Address = testCase.address
+
+		testBmHostBuilder = testBmHostBuilder.WithBMCProtocol(testCase.protocol)
+		assert.Equal(t, testCase.expectedError, testBmHostBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Equal(t, testCase.expectedAddress, testBmHostBuilder.Definition.Spec.BMC.Address)
+		}
+	}
+}
+
+func TestBareMetalHostWithInspectAnnotation(t *testing.T) {
+	testBmHostBuilder := buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject()).
+		WithInspectAnnotation(true)
+	assert.Equal(t, "", testBmHostBuilder.errorMsg)
+	assert.Equal(t, bmhv1alpha1.InspectAnnotationValueDisabled,
+		testBmHostBuilder.Definition.Annotations[bmhv1alpha1.InspectAnnotationPrefix])
+
+	testBmHostBuilder = testBmHostBuilder.WithInspectAnnotation(false)
+	assert.Equal(t, "", testBmHostBuilder.errorMsg)
+	assert.NotContains(t, testBmHostBuilder.Definition.Annotations, bmhv1alpha1.InspectAnnotationPrefix)
+}
+
+func TestBareMetalHostWithHardwareDetailsAnnotation(t *testing.T) {
+	testCases := []struct {
+		hardwareDetails    *bmhv1alpha1.HardwareDetails
+		expectedAnnotation string
+		expectedError      string
+	}{
+		{
+			hardwareDetails:    &bmhv1alpha1.HardwareDetails{Hostname: "test-host", RAMMebibytes: 4096},
+			expectedAnnotation: `"hostname":"test-host"`,
+		},
+		{
+			hardwareDetails: nil,
+			expectedError:   "the baremetalhost hardware details cannot be nil",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBmHostBuilder := buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject()).
+			WithHardwareDetailsAnnotation(testCase.hardwareDetails)
+		assert.Equal(t, testCase.expectedError, testBmHostBuilder.errorMsg)
+
+		if testCase.expectedError == "" {
+			assert.Contains(t,
+				testBmHostBuilder.Definition.Annotations[bmhv1alpha1.HardwareDetailsAnnotation], testCase.expectedAnnotation)
+		}
+	}
+}
+
+func TestBareMetalHostSetStatusAnnotation(t *testing.T) {
+	testCases := []struct {
+		testBmHost    *BmhBuilder
+		expectedError error
+	}{
+		{
+			testBmHost:    buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: nil,
+		},
+		{
+			testBmHost:    buildInValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: fmt.Errorf("not acceptable 'bootMode' value"),
+		},
+	}
+
+	status := bmhv1alpha1.BareMetalHostStatus{
+		OperationalStatus: bmhv1alpha1.OperationalStatusOK,
+		HardwareProfile:   "unknown",
+		PoweredOn:         true,
+		Provisioning: bmhv1alpha1.ProvisionStatus{
+			State: bmhv1alpha1.StateProvisioned,
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.testBmHost.SetStatusAnnotation(status)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Contains(t, testCase.testBmHost.Definition.Annotations, bmhv1alpha1.StatusAnnotation)
+
+			// Check that the status round-trips through the annotation on the cluster.
+			_, err = testCase.testBmHost.Create()
+			assert.Nil(t, err)
+
+			annotatedStatus, err := testCase.testBmHost.GetStatusAnnotation()
+			assert.Nil(t, err)
+			assert.Equal(t, &status, annotatedStatus)
+		}
+	}
+}
+
 func TestBareMetalHostWithOptions(t *testing.T) {
 	testSettings := buildBareMetalHostTestClientWithDummyObject()
 	testBuilder := buildValidBmHostBuilder(testSettings).WithOptions(
@@ -732,6 +885,140 @@ func TestBareMetalHostGetBmhPowerOnStatus(t *testing.T) {
 	}
 }
 
+func TestBareMetalHostGetStatusAnnotation(t *testing.T) {
+	testCases := []struct {
+		testBmHost    *BmhBuilder
+		expectedState bmhv1alpha1.ProvisioningState
+		expectedError string
+	}{
+		{
+			testBmHost: buildValidBmHostBuilder(
+				buildBareMetalHostTestClientWithStatusAnnotation(`{"provisioning":{"state":"ready"}}`)),
+			expectedState: bmhv1alpha1.StateReady,
+		},
+		{
+			testBmHost:    buildValidBmHostBuilder(buildBareMetalHostTestClientWithStatusAnnotation("not-json")),
+			expectedError: "failed to unmarshal bmh annotation baremetalhost.metal3.io/status",
+		},
+		{
+			testBmHost: buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject()),
+			expectedError: "bmh object metallbio in namespace test-namespace does not have annotation " +
+				"baremetalhost.metal3.io/status",
+		},
+		{
+			testBmHost:    buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: "bmh object metallbio does not exist in namespace test-namespace",
+		},
+	}
+
+	for _, testCase := range testCases {
+		status, err := testCase.testBmHost.GetStatusAnnotation()
+
+		if testCase.expectedError != "" {
+			assert.ErrorContains(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.expectedState, status.Provisioning.State)
+	}
+}
+
+func TestBareMetalHostGetHardwareDetailsAnnotation(t *testing.T) {
+	testBmHostBuilder := buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})).
+		WithInspectAnnotation(true).
+		WithHardwareDetailsAnnotation(&bmhv1alpha1.HardwareDetails{Hostname: "test-host", RAMMebibytes: 4096})
+
+	_, err := testBmHostBuilder.GetHardwareDetailsAnnotation()
+	assert.EqualError(t, err, "bmh object metallbio does not exist in namespace test-namespace")
+
+	_, err = testBmHostBuilder.Create()
+	assert.Nil(t, err)
+
+	hardwareDetails, err := testBmHostBuilder.GetHardwareDetailsAnnotation()
+	assert.Nil(t, err)
+	assert.Equal(t, &bmhv1alpha1.HardwareDetails{Hostname: "test-host", RAMMebibytes: 4096}, hardwareDetails)
+}
+
+func TestBareMetalHostDetach(t *testing.T) {
+	testCases := []struct {
+		testBmHost    *BmhBuilder
+		expectedError error
+	}{
+		{
+			testBmHost:    buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject()),
+			expectedError: nil,
+		},
+		{
+			testBmHost:    buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: fmt.Errorf("bmh object metallbio does not exist in namespace test-namespace"),
+		},
+		{
+			testBmHost:    buildInValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject()),
+			expectedError: fmt.Errorf("not acceptable 'bootMode' value"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testBmHost, err := testCase.testBmHost.Detach()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Contains(t, testBmHost.Object.Annotations, bmhv1alpha1.DetachedAnnotation)
+
+			bmHost, err := testBmHost.Get()
+			assert.Nil(t, err)
+			assert.Contains(t, bmHost.Annotations, bmhv1alpha1.DetachedAnnotation)
+		}
+	}
+}
+
+func TestBareMetalHostAttach(t *testing.T) {
+	testCases := []struct {
+		testBmHost    *BmhBuilder
+		detachFirst   bool
+		expectedError error
+	}{
+		{
+			testBmHost:    buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject()),
+			detachFirst:   true,
+			expectedError: nil,
+		},
+		{
+			testBmHost:    buildValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject()),
+			detachFirst:   false,
+			expectedError: nil,
+		},
+		{
+			testBmHost:    buildValidBmHostBuilder(clients.GetTestClients(clients.TestClientParams{})),
+			expectedError: fmt.Errorf("bmh object metallbio does not exist in namespace test-namespace"),
+		},
+		{
+			testBmHost:    buildInValidBmHostBuilder(buildBareMetalHostTestClientWithDummyObject()),
+			expectedError: fmt.Errorf("not acceptable 'bootMode' value"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		if testCase.detachFirst {
+			_, err := testCase.testBmHost.Detach()
+			assert.Nil(t, err)
+		}
+
+		testBmHost, err := testCase.testBmHost.Attach()
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.NotContains(t, testBmHost.Object.Annotations, bmhv1alpha1.DetachedAnnotation)
+
+			bmHost, err := testBmHost.Get()
+			assert.Nil(t, err)
+			assert.NotContains(t, bmHost.Annotations, bmhv1alpha1.DetachedAnnotation)
+		}
+	}
+}
+
 func TestBareMetalHostCreateAndWaitUntilProvisioned(t *testing.T) {
 	testCases := []struct {
 		testBmHost    *BmhBuilder
@@ -1026,6 +1313,18 @@ func buildBareMetalHostTestClientWithDummyObject(state ...bmhv1alpha1.Provisioni
 	})
 }
 
+func buildBareMetalHostTestClientWithStatusAnnotation(statusAnnotation string) *clients.Settings {
+	dummyObjects := buildDummyBmHost(bmhv1alpha1.StateProvisioned)
+
+	if bmHost, ok := dummyObjects[0].(*bmhv1alpha1.BareMetalHost); ok {
+		bmHost.Annotations = map[string]string{bmhv1alpha1.StatusAnnotation: statusAnnotation}
+	}
+
+	return clients.GetTestClients(clients.TestClientParams{
+		K8sMockObjects: dummyObjects,
+	})
+}
+
 func buildDummyBmHost(
 	state bmhv1alpha1.ProvisioningState, operationalStatus ...bmhv1alpha1.OperationalStatus) []runtime.Object {
 	operState := bmhv1alpha1.OperationalStatusOK
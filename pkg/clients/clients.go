@@ -96,6 +96,7 @@ import (
 	storagev1 "k8s.io/api/storage/v1"
 	k8sFakeClient "k8s.io/client-go/kubernetes/fake"
 	fakeRuntimeClient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 	istiov1 "maistra.io/api/core/v1"
@@ -427,6 +428,8 @@ func (settings *Settings) GetAPIClient() (*Settings, error) {
 type TestClientParams struct {
 	K8sMockObjects []runtime.Object
 	GVK            []schema.GroupVersionKind
+	// Interceptors, when set, are used by the fake runtime client to intercept calls, such as to simulate errors.
+	Interceptors *interceptor.Funcs
 
 	// Note: Add more fields below if/when needed.
 }
@@ -461,6 +464,8 @@ func GetTestClients(tcp TestClientParams) *Settings {
 			k8sClientObjects = append(k8sClientObjects, v)
 		case *corev1.Service:
 			k8sClientObjects = append(k8sClientObjects, v)
+		case *corev1.Namespace:
+			k8sClientObjects = append(k8sClientObjects, v)
 		case *corev1.Node:
 			k8sClientObjects = append(k8sClientObjects, v)
 		case *appsv1.Deployment:
@@ -656,8 +661,14 @@ func GetTestClients(tcp TestClientParams) *Settings {
 
 	clientSet.Interface = dynamicFake.NewSimpleDynamicClient(fakeClientScheme, genericClientObjects...)
 	// Add fake runtime client to clientSet runtime client
-	clientSet.Client = fakeRuntimeClient.NewClientBuilder().WithScheme(fakeClientScheme).
-		WithRuntimeObjects(genericClientObjects...).Build()
+	fakeClientBuilder := fakeRuntimeClient.NewClientBuilder().WithScheme(fakeClientScheme).
+		WithRuntimeObjects(genericClientObjects...)
+
+	if tcp.Interceptors != nil {
+		fakeClientBuilder = fakeClientBuilder.WithInterceptorFuncs(*tcp.Interceptors)
+	}
+
+	clientSet.Client = fakeClientBuilder.Build()
 
 	return clientSet
 }
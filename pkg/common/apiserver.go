@@ -0,0 +1,43 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WaitUntilAPIReachable waits up to timeout for the API server to be reachable, such as after a cluster reboot. Every
+// interval, it lists at most one namespace, returning once a list succeeds. The context error is returned if the API
+// server is still not reachable once timeout elapses or ctx is done.
+func WaitUntilAPIReachable(ctx context.Context, apiClient *clients.Settings, interval, timeout time.Duration) error {
+	if apiClient == nil || apiClient.Client == nil {
+		glog.V(100).Info("The apiClient is empty")
+
+		return fmt.Errorf("failed to wait for API server, 'apiClient' parameter is empty")
+	}
+
+	if interval <= 0 {
+		glog.V(100).Infof("The interval %s is less than or equal to zero", interval)
+
+		return fmt.Errorf("failed to wait for API server, 'interval' must be greater than zero")
+	}
+
+	glog.V(100).Infof("Waiting up to %s for the API server to be reachable", timeout)
+
+	return wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		err := apiClient.Client.List(ctx, &corev1.NamespaceList{}, goclient.Limit(1))
+		if err != nil {
+			glog.V(100).Infof("API server is not reachable: %v", err)
+
+			return false, nil
+		}
+
+		return true, nil
+	})
+}
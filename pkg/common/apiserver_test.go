@@ -0,0 +1,70 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestWaitUntilAPIReachable(t *testing.T) {
+	testCases := []struct {
+		failedLists      int
+		expectedAttempts int
+		expectedError    error
+	}{
+		{
+			failedLists:      0,
+			expectedAttempts: 1,
+		},
+		{
+			failedLists:      3,
+			expectedAttempts: 4,
+		},
+		{
+			failedLists:   1000,
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+
+	for _, testCase := range testCases {
+		attempts := 0
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			Interceptors: &interceptor.Funcs{
+				List: func(
+					ctx context.Context, client goclient.WithWatch, list goclient.ObjectList, opts ...goclient.ListOption) error {
+					attempts++
+
+					if attempts <= testCase.failedLists {
+						return fmt.Errorf("connection refused")
+					}
+
+					return client.List(ctx, list, opts...)
+				},
+			},
+		})
+
+		err := WaitUntilAPIReachable(context.TODO(), testSettings, 10*time.Millisecond, 200*time.Millisecond)
+		if testCase.expectedError != nil {
+			assert.ErrorIs(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedAttempts, attempts)
+	}
+
+	// Check that the parameters are validated.
+	err := WaitUntilAPIReachable(context.TODO(), nil, time.Second, time.Second)
+	assert.EqualError(t, err, "failed to wait for API server, 'apiClient' parameter is empty")
+
+	err = WaitUntilAPIReachable(context.TODO(), clients.GetTestClients(clients.TestClientParams{}), 0, time.Second)
+	assert.EqualError(t, err, "failed to wait for API server, 'interval' must be greater than zero")
+}
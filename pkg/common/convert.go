@@ -0,0 +1,42 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ConvertObject converts in to out using the conversion functions registered in scheme. This allows reading an object
+// stored at one version of an API and writing it back at another. An error is returned if either object is nil or if
+// the scheme has no conversion registered between the two types.
+func ConvertObject(scheme *runtime.Scheme, in, out runtime.Object) error {
+	if scheme == nil {
+		glog.V(100).Info("The scheme is empty")
+
+		return fmt.Errorf("failed to convert object, 'scheme' parameter is empty")
+	}
+
+	if in == nil {
+		glog.V(100).Info("The input object is empty")
+
+		return fmt.Errorf("failed to convert object, 'in' parameter is empty")
+	}
+
+	if out == nil {
+		glog.V(100).Info("The output object is empty")
+
+		return fmt.Errorf("failed to convert object, 'out' parameter is empty")
+	}
+
+	glog.V(100).Infof("Converting object of type %T to type %T", in, out)
+
+	err := scheme.Convert(in, out, nil)
+	if err != nil {
+		glog.V(100).Infof("Failed to convert object of type %T to type %T: %v", in, out, err)
+
+		return fmt.Errorf("failed to convert object of type %T to type %T: %w", in, out, err)
+	}
+
+	return nil
+}
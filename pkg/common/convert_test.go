@@ -0,0 +1,134 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	testGroupVersionV1 = schema.GroupVersion{Group: "test.eco-goinfra.io", Version: "v1"}
+	testGroupVersionV2 = schema.GroupVersion{Group: "test.eco-goinfra.io", Version: "v2"}
+)
+
+// testWidgetV1 is a test type that stores the size of a widget as a string.
+type testWidgetV1 struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Size              string `json:"size"`
+}
+
+func (widget *testWidgetV1) DeepCopyObject() runtime.Object {
+	out := *widget
+	widget.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+
+	return &out
+}
+
+// testWidgetV2 is a test type that stores the size of a widget as a number of units.
+type testWidgetV2 struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	SizeUnits         int `json:"sizeUnits"`
+}
+
+func (widget *testWidgetV2) DeepCopyObject() runtime.Object {
+	out := *widget
+	widget.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+
+	return &out
+}
+
+func TestConvertObject(t *testing.T) {
+	testCases := []struct {
+		registerConversion bool
+		size               string
+		expectedUnits      int
+		expectedError      string
+	}{
+		{
+			registerConversion: true,
+			size:               "3",
+			expectedUnits:      3,
+		},
+		{
+			registerConversion: true,
+			size:               "large",
+			expectedError: "failed to convert object of type *common.testWidgetV1 to type *common.testWidgetV2: " +
+				"invalid widget size large",
+		},
+		{
+			registerConversion: false,
+			size:               "3",
+			expectedError: "failed to convert object of type *common.testWidgetV1 to type *common.testWidgetV2: " +
+				"converting (common.testWidgetV1) to (common.testWidgetV2): unknown conversion",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testScheme := buildTestWidgetScheme(t, testCase.registerConversion)
+
+		in := &testWidgetV1{ObjectMeta: metav1.ObjectMeta{Name: "test"}, Size: testCase.size}
+		out := &testWidgetV2{}
+
+		err := ConvertObject(testScheme, in, out)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test", out.Name)
+		assert.Equal(t, testCase.expectedUnits, out.SizeUnits)
+	}
+
+	// Check that the parameters are validated.
+	testScheme := buildTestWidgetScheme(t, true)
+
+	err := ConvertObject(nil, &testWidgetV1{}, &testWidgetV2{})
+	assert.EqualError(t, err, "failed to convert object, 'scheme' parameter is empty")
+
+	err = ConvertObject(testScheme, nil, &testWidgetV2{})
+	assert.EqualError(t, err, "failed to convert object, 'in' parameter is empty")
+
+	err = ConvertObject(testScheme, &testWidgetV1{}, nil)
+	assert.EqualError(t, err, "failed to convert object, 'out' parameter is empty")
+}
+
+// buildTestWidgetScheme returns a scheme with both test widget versions registered and, if registerConversion is
+// true, a conversion from v1 to v2.
+func buildTestWidgetScheme(t *testing.T, registerConversion bool) *runtime.Scheme {
+	t.Helper()
+
+	testScheme := runtime.NewScheme()
+	testScheme.AddKnownTypeWithName(testGroupVersionV1.WithKind("Widget"), &testWidgetV1{})
+	testScheme.AddKnownTypeWithName(testGroupVersionV2.WithKind("Widget"), &testWidgetV2{})
+
+	if !registerConversion {
+		return testScheme
+	}
+
+	err := testScheme.AddConversionFunc((*testWidgetV1)(nil), (*testWidgetV2)(nil),
+		func(in, out interface{}, scope conversion.Scope) error {
+			widgetV1 := in.(*testWidgetV1)
+			widgetV2 := out.(*testWidgetV2)
+
+			widgetV2.ObjectMeta = widgetV1.ObjectMeta
+
+			_, err := fmt.Sscanf(widgetV1.Size, "%d", &widgetV2.SizeUnits)
+			if err != nil {
+				return fmt.Errorf("invalid widget size %s", widgetV1.Size)
+			}
+
+			return nil
+		})
+	assert.NoError(t, err)
+
+	return testScheme
+}
@@ -0,0 +1,139 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/daemonset"
+	"github.com/openshift-kni/eco-goinfra/pkg/deployment"
+	"github.com/openshift-kni/eco-goinfra/pkg/statefulset"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// DeploymentKind is the kind of a Deployment workload.
+	DeploymentKind = "Deployment"
+	// DaemonSetKind is the kind of a DaemonSet workload.
+	DaemonSetKind = "DaemonSet"
+	// StatefulSetKind is the kind of a StatefulSet workload.
+	StatefulSetKind = "StatefulSet"
+)
+
+// rolloutPollInterval is the interval between checks of a workload while waiting for its rollout.
+var rolloutPollInterval = 3 * time.Second
+
+// WaitForWorkloadRollout waits up to timeout for the rollout of the workload of the given kind, which must be one of
+// DeploymentKind, DaemonSetKind, or StatefulSetKind, to complete. The rollout is complete once the workload controller
+// has observed its latest generation and its updated and ready replicas both equal its desired replicas. Errors getting
+// the workload are retried, so the context error is returned if the rollout does not complete in time.
+func WaitForWorkloadRollout(
+	ctx context.Context, apiClient *clients.Settings, kind, name, namespace string, timeout time.Duration) error {
+	if apiClient == nil {
+		glog.V(100).Info("The apiClient is empty")
+
+		return fmt.Errorf("failed to wait for workload rollout, 'apiClient' parameter is empty")
+	}
+
+	if name == "" {
+		glog.V(100).Info("The workload name is empty")
+
+		return fmt.Errorf("failed to wait for workload rollout, 'name' parameter is empty")
+	}
+
+	if namespace == "" {
+		glog.V(100).Info("The workload namespace is empty")
+
+		return fmt.Errorf("failed to wait for workload rollout, 'namespace' parameter is empty")
+	}
+
+	var isRolledOut func() (bool, error)
+
+	switch kind {
+	case DeploymentKind:
+		isRolledOut = func() (bool, error) { return isDeploymentRolledOut(apiClient, name, namespace) }
+	case DaemonSetKind:
+		isRolledOut = func() (bool, error) { return isDaemonSetRolledOut(apiClient, name, namespace) }
+	case StatefulSetKind:
+		isRolledOut = func() (bool, error) { return isStatefulSetRolledOut(apiClient, name, namespace) }
+	default:
+		glog.V(100).Infof("The workload kind %s is not supported", kind)
+
+		return fmt.Errorf("failed to wait for workload rollout, 'kind' %s is not one of %s, %s, or %s",
+			kind, DeploymentKind, DaemonSetKind, StatefulSetKind)
+	}
+
+	glog.V(100).Infof("Waiting up to %s for rollout of %s %s in namespace %s", timeout, kind, name, namespace)
+
+	return wait.PollUntilContextTimeout(ctx, rolloutPollInterval, timeout, true, func(context.Context) (bool, error) {
+		rolledOut, err := isRolledOut()
+		if err != nil {
+			glog.V(100).Infof("Failed to get %s %s in namespace %s: %v", kind, name, namespace, err)
+
+			return false, nil
+		}
+
+		return rolledOut, nil
+	})
+}
+
+// isDeploymentRolledOut pulls the deployment and returns whether its rollout is complete.
+func isDeploymentRolledOut(apiClient *clients.Settings, name, namespace string) (bool, error) {
+	builder, err := deployment.Pull(apiClient, name, namespace)
+	if err != nil {
+		return false, err
+	}
+
+	desired := int32(1)
+	if builder.Object.Spec.Replicas != nil {
+		desired = *builder.Object.Spec.Replicas
+	}
+
+	status := builder.Object.Status
+
+	glog.V(100).Infof("Deployment %s in namespace %s has %d updated and %d ready of %d desired replicas",
+		name, namespace, status.UpdatedReplicas, status.ReadyReplicas, desired)
+
+	return status.ObservedGeneration >= builder.Object.Generation &&
+		status.UpdatedReplicas == desired && status.ReadyReplicas == desired, nil
+}
+
+// isDaemonSetRolledOut pulls the daemonset and returns whether its rollout is complete.
+func isDaemonSetRolledOut(apiClient *clients.Settings, name, namespace string) (bool, error) {
+	builder, err := daemonset.Pull(apiClient, name, namespace)
+	if err != nil {
+		return false, err
+	}
+
+	status := builder.Object.Status
+
+	glog.V(100).Infof("DaemonSet %s in namespace %s has %d updated and %d ready of %d desired pods",
+		name, namespace, status.UpdatedNumberScheduled, status.NumberReady, status.DesiredNumberScheduled)
+
+	return status.ObservedGeneration >= builder.Object.Generation &&
+		status.UpdatedNumberScheduled == status.DesiredNumberScheduled &&
+		status.NumberReady == status.DesiredNumberScheduled, nil
+}
+
+// isStatefulSetRolledOut pulls the statefulset and returns whether its rollout is complete.
+func isStatefulSetRolledOut(apiClient *clients.Settings, name, namespace string) (bool, error) {
+	builder, err := statefulset.Pull(apiClient, name, namespace)
+	if err != nil {
+		return false, err
+	}
+
+	desired := int32(1)
+	if builder.Object.Spec.Replicas != nil {
+		desired = *builder.Object.Spec.Replicas
+	}
+
+	status := builder.Object.Status
+
+	glog.V(100).Infof("StatefulSet %s in namespace %s has %d updated and %d ready of %d desired replicas",
+		name, namespace, status.UpdatedReplicas, status.ReadyReplicas, desired)
+
+	return status.ObservedGeneration >= builder.Object.Generation &&
+		status.UpdatedReplicas == desired && status.ReadyReplicas == desired, nil
+}
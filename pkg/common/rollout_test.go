@@ -0,0 +1,182 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	defaultRolloutName      = "rollout-test"
+	defaultRolloutNamespace = "rollout-test-ns"
+)
+
+func TestWaitForWorkloadRollout(t *testing.T) {
+	rolloutPollInterval = 10 * time.Millisecond
+
+	testCases := []struct {
+		kind          string
+		object        runtime.Object
+		name          string
+		namespace     string
+		expectedError string
+	}{
+		{
+			kind:   DeploymentKind,
+			object: buildDummyDeployment(2, 2, 2),
+		},
+		{
+			kind:          DeploymentKind,
+			object:        buildDummyDeployment(2, 2, 1),
+			expectedError: context.DeadlineExceeded.Error(),
+		},
+		{
+			kind:   DaemonSetKind,
+			object: buildDummyDaemonSet(3, 3, 3),
+		},
+		{
+			kind:          DaemonSetKind,
+			object:        buildDummyDaemonSet(3, 1, 3),
+			expectedError: context.DeadlineExceeded.Error(),
+		},
+		{
+			kind:   StatefulSetKind,
+			object: buildDummyStatefulSet(1, 1, 1),
+		},
+		{
+			kind:          StatefulSetKind,
+			object:        buildDummyStatefulSet(1, 0, 0),
+			expectedError: context.DeadlineExceeded.Error(),
+		},
+		{
+			kind:          DeploymentKind,
+			expectedError: context.DeadlineExceeded.Error(),
+		},
+		{
+			kind:   "ReplicaSet",
+			object: buildDummyDeployment(1, 1, 1),
+			expectedError: "failed to wait for workload rollout, " +
+				"'kind' ReplicaSet is not one of Deployment, DaemonSet, or StatefulSet",
+		},
+		{
+			kind:          DeploymentKind,
+			name:          "-",
+			expectedError: "failed to wait for workload rollout, 'name' parameter is empty",
+		},
+		{
+			kind:          DeploymentKind,
+			namespace:     "-",
+			expectedError: "failed to wait for workload rollout, 'namespace' parameter is empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.object != nil {
+			runtimeObjects = append(runtimeObjects, testCase.object)
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+
+		name := defaultRolloutName
+		if testCase.name == "-" {
+			name = ""
+		}
+
+		namespace := defaultRolloutNamespace
+		if testCase.namespace == "-" {
+			namespace = ""
+		}
+
+		err := WaitForWorkloadRollout(context.TODO(), testSettings, testCase.kind, name, namespace, 100*time.Millisecond)
+		if testCase.expectedError == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, testCase.expectedError)
+		}
+	}
+
+	err := WaitForWorkloadRollout(
+		context.TODO(), nil, DeploymentKind, defaultRolloutName, defaultRolloutNamespace, time.Second)
+	assert.EqualError(t, err, "failed to wait for workload rollout, 'apiClient' parameter is empty")
+}
+
+func TestWaitForWorkloadRolloutProgressing(t *testing.T) {
+	rolloutPollInterval = 10 * time.Millisecond
+
+	deployment := buildDummyDeployment(2, 0, 0)
+	testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: []runtime.Object{deployment}})
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+
+		deployment.Status.UpdatedReplicas = 2
+		deployment.Status.ReadyReplicas = 2
+
+		_, _ = testSettings.Deployments(defaultRolloutNamespace).UpdateStatus(
+			context.TODO(), deployment, metav1.UpdateOptions{})
+	}()
+
+	err := WaitForWorkloadRollout(
+		context.TODO(), testSettings, DeploymentKind, defaultRolloutName, defaultRolloutNamespace, time.Second)
+	assert.NoError(t, err)
+}
+
+func buildDummyDeployment(replicas, updated, ready int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       defaultRolloutName,
+			Namespace:  defaultRolloutNamespace,
+			Generation: 1,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 1,
+			UpdatedReplicas:    updated,
+			ReadyReplicas:      ready,
+		},
+	}
+}
+
+func buildDummyDaemonSet(desired, updated, ready int32) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       defaultRolloutName,
+			Namespace:  defaultRolloutNamespace,
+			Generation: 1,
+		},
+		Status: appsv1.DaemonSetStatus{
+			ObservedGeneration:     1,
+			DesiredNumberScheduled: desired,
+			UpdatedNumberScheduled: updated,
+			NumberReady:            ready,
+		},
+	}
+}
+
+func buildDummyStatefulSet(replicas, updated, ready int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       defaultRolloutName,
+			Namespace:  defaultRolloutNamespace,
+			Generation: 1,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 1,
+			UpdatedReplicas:    updated,
+			ReadyReplicas:      ready,
+		},
+	}
+}
@@ -95,6 +95,33 @@ func (builder *Builder) Exists() bool {
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
+// ExistsOrError checks whether the given ingresscontroller exists. Unlike Exists, errors other than NotFound, such as
+// RBAC or connectivity errors, are returned rather than being treated as the ingresscontroller existing.
+func (builder *Builder) ExistsOrError() (bool, error) {
+	if valid, err := builder.validate(); !valid {
+		return false, err
+	}
+
+	glog.V(100).Infof("Checking if ingresscontroller %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		glog.V(100).Infof("Failed to check if ingresscontroller %s exists in namespace %s: %v",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+
+		return false, fmt.Errorf("failed to check if ingresscontroller exists: %w", err)
+	}
+
+	return true, nil
+}
+
 // Update renovates a Builder in the cluster and stores the created object in struct.
 func (builder *Builder) Update() (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
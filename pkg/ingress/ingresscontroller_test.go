@@ -1,13 +1,18 @@
 package ingress
 
 import (
+	"context"
 	"testing"
 
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/stretchr/testify/assert"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 func TestIngressPull(t *testing.T) {
@@ -307,6 +312,74 @@ func TestIngressExists(t *testing.T) {
 	}
 }
 
+func TestIngressExistsOrError(t *testing.T) {
+	forbiddenGetFunc := func(
+		ctx context.Context, client goclient.WithWatch, key goclient.ObjectKey, obj goclient.Object,
+		opts ...goclient.GetOption) error {
+		return k8serrors.NewForbidden(
+			schema.GroupResource{Group: "operator.openshift.io", Resource: "ingresscontrollers"}, key.Name, nil)
+	}
+
+	testCases := []struct {
+		ingressExistsAlready bool
+		interceptors         *interceptor.Funcs
+		expectedExists       bool
+		expectedForbidden    bool
+	}{
+		{
+			ingressExistsAlready: true,
+			expectedExists:       true,
+		},
+		{
+			ingressExistsAlready: false,
+			expectedExists:       false,
+		},
+		{
+			ingressExistsAlready: true,
+			interceptors:         &interceptor.Funcs{Get: forbiddenGetFunc},
+			expectedExists:       false,
+			expectedForbidden:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.ingressExistsAlready {
+			runtimeObjects = append(runtimeObjects, &operatorv1.IngressController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+			})
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{
+			K8sMockObjects: runtimeObjects,
+			Interceptors:   testCase.interceptors,
+		})
+
+		testBuilder := &Builder{
+			apiClient: testSettings,
+			Definition: &operatorv1.IngressController{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: "test",
+				},
+			},
+		}
+
+		exists, err := testBuilder.ExistsOrError()
+		assert.Equal(t, testCase.expectedExists, exists)
+
+		if testCase.expectedForbidden {
+			assert.True(t, k8serrors.IsForbidden(err))
+		} else {
+			assert.Nil(t, err)
+		}
+	}
+}
+
 // func TestIngressUpdate(t *testing.T) {
 // 	testCases := []struct {
 // 		ingressExistsAlready bool
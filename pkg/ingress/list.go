@@ -0,0 +1,102 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListIngressControllers returns ingresscontroller inventory in the given namespace.
+func ListIngressControllers(
+	apiClient *clients.Settings, nsname string, options ...goclient.ListOptions) ([]*Builder, error) {
+	if apiClient == nil || apiClient.Client == nil {
+		glog.V(100).Infof("IngressControllers 'apiClient' parameter can not be empty")
+
+		return nil, fmt.Errorf("failed to list ingresscontrollers, 'apiClient' parameter is empty")
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("ingresscontroller 'nsname' parameter can not be empty")
+
+		return nil, fmt.Errorf("failed to list ingresscontrollers, 'nsname' parameter is empty")
+	}
+
+	logMessage := fmt.Sprintf("Listing ingresscontrollers in the namespace %s", nsname)
+	passedOptions := goclient.ListOptions{}
+
+	if len(options) > 1 {
+		glog.V(100).Infof("'options' parameter must be empty or single-valued")
+
+		return nil, fmt.Errorf("error: more than one ListOptions was passed")
+	}
+
+	if len(options) == 1 {
+		passedOptions = options[0]
+		logMessage += fmt.Sprintf(" with the options %v", passedOptions)
+	}
+
+	passedOptions.Namespace = nsname
+
+	glog.V(100).Infof(logMessage)
+
+	return list(apiClient, passedOptions)
+}
+
+// ListIngressControllersInAllNamespaces lists the ingresscontrollers across all namespaces on the provided cluster.
+func ListIngressControllersInAllNamespaces(
+	apiClient *clients.Settings, options ...goclient.ListOptions) ([]*Builder, error) {
+	if apiClient == nil || apiClient.Client == nil {
+		glog.V(100).Info("IngressController's 'apiClient' parameter cannot be empty")
+
+		return nil, fmt.Errorf("failed to list ingresscontrollers, 'apiClient' parameter is empty")
+	}
+
+	logMessage := "Listing ingresscontrollers in all namespaces"
+	passedOptions := goclient.ListOptions{}
+
+	if len(options) > 1 {
+		glog.V(100).Info("'options' parameter must be empty or single-valued")
+
+		return nil, fmt.Errorf("error: more than one ListOptions was passed")
+	}
+
+	if len(options) == 1 {
+		passedOptions = options[0]
+		logMessage += fmt.Sprintf(" with the options %v", passedOptions)
+	}
+
+	glog.V(100).Info(logMessage)
+
+	return list(apiClient, passedOptions)
+}
+
+// list lists the ingresscontrollers according to the provided options.
+func list(apiClient *clients.Settings, options goclient.ListOptions) ([]*Builder, error) {
+	var ingressList operatorv1.IngressControllerList
+	err := apiClient.List(context.TODO(), &ingressList, &options)
+
+	if err != nil {
+		glog.V(100).Infof("Failed to list ingresscontrollers due to %s", err.Error())
+
+		return nil, err
+	}
+
+	var ingressObjects []*Builder
+
+	for _, ingressController := range ingressList.Items {
+		copiedIngress := ingressController
+		ingressBuilder := &Builder{
+			apiClient:  apiClient,
+			Object:     &copiedIngress,
+			Definition: &copiedIngress,
+		}
+
+		ingressObjects = append(ingressObjects, ingressBuilder)
+	}
+
+	return ingressObjects, nil
+}
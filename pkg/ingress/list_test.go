@@ -0,0 +1,141 @@
+package ingress
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestIngressListIngressControllers(t *testing.T) {
+	testCases := []struct {
+		nsName        string
+		listOptions   []goclient.ListOptions
+		expectedError error
+		client        bool
+		expectedCount int
+	}{
+		{
+			nsName:        "test-namespace",
+			expectedError: nil,
+			client:        true,
+			expectedCount: 1,
+		},
+		{
+			nsName:        "",
+			expectedError: fmt.Errorf("failed to list ingresscontrollers, 'nsname' parameter is empty"),
+			client:        true,
+		},
+		{
+			nsName:        "test-namespace",
+			listOptions:   []goclient.ListOptions{{LabelSelector: labels.NewSelector()}},
+			expectedError: nil,
+			client:        true,
+			expectedCount: 1,
+		},
+		{
+			nsName:        "test-namespace",
+			listOptions:   []goclient.ListOptions{{LabelSelector: labels.NewSelector()}, {LabelSelector: labels.NewSelector()}},
+			expectedError: fmt.Errorf("error: more than one ListOptions was passed"),
+			client:        true,
+		},
+		{
+			nsName:        "test-namespace",
+			expectedError: fmt.Errorf("failed to list ingresscontrollers, 'apiClient' parameter is empty"),
+			client:        false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		var testSettings *clients.Settings
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{
+				K8sMockObjects: buildDummyIngressControllers(),
+			})
+		}
+
+		ingressBuilders, err := ListIngressControllers(testSettings, testCase.nsName, testCase.listOptions...)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Len(t, ingressBuilders, testCase.expectedCount)
+
+			for _, ingressBuilder := range ingressBuilders {
+				assert.Equal(t, testCase.nsName, ingressBuilder.Object.Namespace)
+			}
+		}
+	}
+}
+
+func TestIngressListIngressControllersInAllNamespaces(t *testing.T) {
+	testCases := []struct {
+		listOptions   []goclient.ListOptions
+		expectedError error
+		client        bool
+		expectedCount int
+	}{
+		{
+			listOptions:   nil,
+			expectedError: nil,
+			client:        true,
+			expectedCount: 2,
+		},
+		{
+			listOptions:   []goclient.ListOptions{{LabelSelector: labels.NewSelector()}},
+			expectedError: nil,
+			client:        true,
+			expectedCount: 2,
+		},
+		{
+			listOptions:   []goclient.ListOptions{{LabelSelector: labels.NewSelector()}, {LabelSelector: labels.NewSelector()}},
+			expectedError: fmt.Errorf("error: more than one ListOptions was passed"),
+			client:        true,
+		},
+		{
+			listOptions:   nil,
+			expectedError: fmt.Errorf("failed to list ingresscontrollers, 'apiClient' parameter is empty"),
+			client:        false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		var testSettings *clients.Settings
+
+		if testCase.client {
+			testSettings = clients.GetTestClients(clients.TestClientParams{
+				K8sMockObjects: buildDummyIngressControllers(),
+			})
+		}
+
+		ingressBuilders, err := ListIngressControllersInAllNamespaces(testSettings, testCase.listOptions...)
+		assert.Equal(t, testCase.expectedError, err)
+
+		if testCase.expectedError == nil {
+			assert.Len(t, ingressBuilders, testCase.expectedCount)
+		}
+	}
+}
+
+func buildDummyIngressControllers() []runtime.Object {
+	return []runtime.Object{
+		&operatorv1.IngressController{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "default",
+				Namespace: "test-namespace",
+			},
+		},
+		&operatorv1.IngressController{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "default",
+				Namespace: "other-namespace",
+			},
+		},
+	}
+}
@@ -3,6 +3,7 @@ package namespace
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
@@ -13,6 +14,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/ptr"
 	"k8s.io/utils/strings/slices"
@@ -208,6 +210,45 @@ func (builder *Builder) Exists() bool {
 	return err == nil || !k8serrors.IsNotFound(err)
 }
 
+// EnsureNamespace creates the namespace with the given labels if it does not already exist, so that namespaced
+// operations can follow. An existing namespace is left unchanged, making repeated calls a no-op.
+func EnsureNamespace(ctx context.Context, apiClient *clients.Settings, name string, labels map[string]string) error {
+	glog.V(100).Infof("Ensuring namespace %s exists", name)
+
+	builder := NewBuilder(apiClient, name).WithMultipleLabels(labels)
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		glog.V(100).Infof("The namespace name %s is invalid: %v", name, errs)
+
+		return fmt.Errorf("namespace 'name' %s is invalid: %s", name, strings.Join(errs, ", "))
+	}
+
+	_, err := builder.apiClient.Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		glog.V(100).Infof("Namespace %s already exists", name)
+
+		return nil
+	}
+
+	if !k8serrors.IsNotFound(err) {
+		glog.V(100).Infof("Failed to get namespace %s: %v", name, err)
+
+		return fmt.Errorf("failed to get namespace %s: %w", name, err)
+	}
+
+	_, err = builder.apiClient.Namespaces().Create(ctx, builder.Definition, metav1.CreateOptions{})
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		glog.V(100).Infof("Failed to create namespace %s: %v", name, err)
+
+		return fmt.Errorf("failed to create namespace %s: %w", name, err)
+	}
+
+	return nil
+}
+
 // Pull loads existing namespace in to Builder struct.
 func Pull(apiClient *clients.Settings, nsname string) (*Builder, error) {
 	glog.V(100).Infof("Pulling existing namespace: %s from cluster", nsname)
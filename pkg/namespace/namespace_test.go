@@ -1 +1,89 @@
 package namespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const defaultNamespaceName = "test-namespace"
+
+func TestEnsureNamespace(t *testing.T) {
+	testCases := []struct {
+		name           string
+		existing       bool
+		labels         map[string]string
+		expectedLabels map[string]string
+		expectedError  string
+	}{
+		{
+			name:           defaultNamespaceName,
+			existing:       false,
+			labels:         map[string]string{"test": "label"},
+			expectedLabels: map[string]string{"test": "label"},
+		},
+		{
+			// An existing namespace is left unchanged.
+			name:           defaultNamespaceName,
+			existing:       true,
+			labels:         map[string]string{"test": "label"},
+			expectedLabels: map[string]string{"existing": "label"},
+		},
+		{
+			name:          "",
+			expectedError: "namespace 'name' cannot be empty",
+		},
+		{
+			name: "Invalid_Name",
+			expectedError: "namespace 'name' Invalid_Name is invalid: a lowercase RFC 1123 label must consist of " +
+				"lower case alphanumeric characters or '-', and must start and end with an alphanumeric character " +
+				"(e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')",
+		},
+		{
+			name:          defaultNamespaceName,
+			labels:        map[string]string{"": "label"},
+			expectedError: "'key' cannot be empty",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var runtimeObjects []runtime.Object
+
+		if testCase.existing {
+			runtimeObjects = append(runtimeObjects, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   testCase.name,
+					Labels: map[string]string{"existing": "label"},
+				},
+			})
+		}
+
+		testSettings := clients.GetTestClients(clients.TestClientParams{K8sMockObjects: runtimeObjects})
+
+		err := EnsureNamespace(context.TODO(), testSettings, testCase.name, testCase.labels)
+		if testCase.expectedError != "" {
+			assert.EqualError(t, err, testCase.expectedError)
+
+			continue
+		}
+
+		assert.NoError(t, err)
+
+		namespace, err := testSettings.Namespaces().Get(context.TODO(), testCase.name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expectedLabels, namespace.Labels)
+
+		// Ensuring the namespace again is a no-op.
+		err = EnsureNamespace(context.TODO(), testSettings, testCase.name, testCase.labels)
+		assert.NoError(t, err)
+	}
+
+	// Check that the apiClient is required.
+	err := EnsureNamespace(context.TODO(), nil, defaultNamespaceName, nil)
+	assert.EqualError(t, err, "NameSpace builder cannot have nil apiClient")
+}
@@ -0,0 +1,79 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Retry runs op until it succeeds, retrying up to retries times while retryable returns true for its error. The wait
+// before the first retry is backoff and it doubles after each retry. A nil retryable retries every error. The error of
+// op is returned as soon as it is not retryable, or once all retries have failed. If ctx is done between attempts, its
+// error is returned, wrapping the error of the last attempt.
+func Retry(
+	ctx context.Context, op func() error, retryable func(error) bool, retries int, backoff time.Duration) error {
+	if op == nil {
+		glog.V(100).Info("The retry 'op' is nil")
+
+		return fmt.Errorf("retry 'op' cannot be nil")
+	}
+
+	if retries < 0 {
+		glog.V(100).Infof("The retry count %d is negative", retries)
+
+		return fmt.Errorf("retry 'retries' cannot be negative")
+	}
+
+	if backoff < 0 {
+		glog.V(100).Infof("The retry backoff %s is negative", backoff)
+
+		return fmt.Errorf("retry 'backoff' cannot be negative")
+	}
+
+	var (
+		opErr   error
+		attempt int
+	)
+
+	steps := retries + 1
+
+	err := wait.ExponentialBackoffWithContext(ctx, wait.Backoff{
+		Duration: backoff,
+		Factor:   2,
+		Steps:    steps,
+	}, func(context.Context) (bool, error) {
+		attempt++
+
+		opErr = op()
+		if opErr == nil {
+			return true, nil
+		}
+
+		if retryable != nil && !retryable(opErr) {
+			return false, opErr
+		}
+
+		glog.V(100).Infof("Attempt %d of %d failed: %v", attempt, steps, opErr)
+
+		return false, nil
+	})
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		if opErr == nil {
+			return err
+		}
+
+		return fmt.Errorf("%w (last error: %w)", err, opErr)
+	case opErr != nil:
+		return opErr
+	default:
+		return err
+	}
+}
@@ -0,0 +1,129 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errRetryable    = errors.New("retryable error")
+	errNotRetryable = errors.New("not retryable error")
+)
+
+func TestRetry(t *testing.T) {
+	testCases := []struct {
+		name             string
+		errs             []error
+		retries          int
+		expectedAttempts int
+		expectedError    error
+	}{
+		{
+			name:             "succeeds first time",
+			errs:             nil,
+			retries:          2,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retryable error eventually succeeds",
+			errs:             []error{errRetryable, errRetryable},
+			retries:          2,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "retryable error exhausts retries",
+			errs:             []error{errRetryable, errRetryable, errRetryable},
+			retries:          1,
+			expectedAttempts: 2,
+			expectedError:    errRetryable,
+		},
+		{
+			name:             "non-retryable error fails immediately",
+			errs:             []error{errNotRetryable},
+			retries:          2,
+			expectedAttempts: 1,
+			expectedError:    errNotRetryable,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			attempts := 0
+
+			err := Retry(context.TODO(), func() error {
+				attempts++
+
+				if attempts <= len(testCase.errs) {
+					return testCase.errs[attempts-1]
+				}
+
+				return nil
+			}, isRetryable, testCase.retries, time.Millisecond)
+
+			assert.Equal(t, testCase.expectedAttempts, attempts)
+
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRetryContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	attempts := 0
+
+	// The context is cancelled during the first backoff, which is far longer than the test should take.
+	err := Retry(ctx, func() error {
+		attempts++
+
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		return errRetryable
+	}, isRetryable, 2, time.Minute)
+
+	assert.Equal(t, 1, attempts)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, errRetryable)
+}
+
+func TestRetryValidation(t *testing.T) {
+	testCases := []struct {
+		op            func() error
+		retries       int
+		backoff       time.Duration
+		expectedError string
+	}{
+		{
+			op:            nil,
+			expectedError: "retry 'op' cannot be nil",
+		},
+		{
+			op:            func() error { return nil },
+			retries:       -1,
+			expectedError: "retry 'retries' cannot be negative",
+		},
+		{
+			op:            func() error { return nil },
+			backoff:       -time.Second,
+			expectedError: "retry 'backoff' cannot be negative",
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := Retry(context.TODO(), testCase.op, nil, testCase.retries, testCase.backoff)
+		assert.EqualError(t, err, testCase.expectedError)
+	}
+}
+
+func isRetryable(err error) bool {
+	return errors.Is(err, errRetryable)
+}